@@ -48,7 +48,7 @@ func getSetup(driver neo4j.Driver) *neo4j.Node {
 	case 0:
 		return nil
 	case 1:
-		node := records[0].Values[0].(neo4j.Node)
+		node := records[0].AllValues()[0].(neo4j.Node)
 		return &node
 	default:
 		panic("More than one setup")
@@ -109,7 +109,7 @@ func iterMxL(driver neo4j.Driver) {
 	var record *neo4j.Record
 	for result.NextRecord(&record) {
 		num++
-		node := record.Values[0].(neo4j.Node)
+		node := record.AllValues()[0].(neo4j.Node)
 		if len(node.Props) != iterMxLNUMPROPS {
 			panic("Num props differ")
 		}
@@ -144,6 +144,29 @@ func iterMxL18(driver neo4j18.Driver) {
 	}
 }
 
+// Iterates over wide records but only accesses their first, narrow, value
+func iterMxLFirst(driver neo4j.Driver) {
+	sess := driver.NewSession(neo4j.SessionConfig{})
+	defer sess.Close()
+
+	result, err := sess.Run("MATCH (n:IterMxL) RETURN n.`0` AS first, n", nil)
+	if err != nil {
+		panic(err)
+	}
+
+	num := 0
+	var record *neo4j.Record
+	for result.NextRecord(&record) {
+		num++
+		if _, found := record.Get("first"); !found {
+			panic("No first value")
+		}
+	}
+	if num != iterMxLNUMRECS {
+		panic(fmt.Sprintf("Num records differ: %d vs %d", num, iterMxLNUMRECS))
+	}
+}
+
 func buildParamsLMap() map[string]interface{} {
 	m := map[string]interface{}{}
 	// Bunch of ints
@@ -191,7 +214,7 @@ func getS(driver neo4j.Driver, n int) {
 			if !res.NextRecord(&rec) {
 				panic("no record")
 			}
-			return int(rec.Values[0].(int64)), nil
+			return int(rec.AllValues()[0].(int64)), nil
 		})
 		if x.(int) != i {
 			panic("!= i")
@@ -267,6 +290,12 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	lazyDriver, err := neo4j.NewDriver(os.Args[1], neo4j.BasicAuth(os.Args[2], os.Args[3], ""), func(conf *neo4j.Config) {
+		conf.LazyRecordDecoding = true
+	})
+	if err != nil {
+		panic(err)
+	}
 
 	// Build the setup if needed
 	buildSetup(driver, getSetup(driver))
@@ -291,4 +320,9 @@ func main() {
 	dur, mem = perf(func() { params(driver, m, 10) }, func() { params(driver, m, 1000) })
 	dur18, mem18 = perf(func() { params18(driver18, m, 10) }, func() { params18(driver18, m, 1000) })
 	printRes("paramsL", dur, dur18, mem, mem18)
+
+	// Baseline is the eager decoding of the same driver version
+	dur, mem = perf(func() { iterMxLFirst(lazyDriver) }, func() { iterMxLFirst(lazyDriver) })
+	durEager, memEager := perf(func() { iterMxLFirst(driver) }, func() { iterMxLFirst(driver) })
+	printRes("iterMxLLazy", dur, durEager, mem, memEager)
 }
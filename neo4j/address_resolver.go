@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/connector"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
+)
+
+// ServerAddressResolverWithContext resolves the address of a router into the addresses of the servers to connect to,
+// see Config.AddressResolverWithContext
+type ServerAddressResolverWithContext interface {
+	// ResolveAddress returns the addresses the router address resolves to.
+	// When an error is returned, the driver connects to the address as it is.
+	ResolveAddress(ctx context.Context, address ServerAddress) ([]ServerAddress, error)
+}
+
+// ServerAddressResolverWithContextFunc turns a function into a ServerAddressResolverWithContext
+type ServerAddressResolverWithContextFunc func(ctx context.Context, address ServerAddress) ([]ServerAddress, error)
+
+func (f ServerAddressResolverWithContextFunc) ResolveAddress(ctx context.Context, address ServerAddress) ([]ServerAddress, error) {
+	return f(ctx, address)
+}
+
+// DnsResolverConfig configures the resolver returned by NewDnsResolver
+type DnsResolverConfig struct {
+	// SrvService is the service of the DNS SRV records to look up, for instance "bolt" to look up the
+	// _bolt._tcp.<hostname> records. The targets and ports of the records replace the address.
+	// Addresses without SRV records are resolved with their A and AAAA records.
+	//
+	// default: "" (no SRV lookup)
+	SrvService string
+	// Resolver performs the DNS lookups.
+	//
+	// default: net.DefaultResolver
+	Resolver *net.Resolver
+}
+
+// NewDnsResolver returns a resolver expanding host names into all the IPv4 and IPv6 addresses of their DNS A and AAAA
+// records, and optionally into the servers of their SRV records. IP addresses are left as they are.
+// It is useful when the cluster members are only exposed through DNS, such as with Kubernetes headless services:
+//
+//	driver, err = NewDriverWithContext("neo4j://neo4j.default.svc.cluster.local:7687", auth, func(config *Config) {
+//		config.AddressResolverWithContext = NewDnsResolver(DnsResolverConfig{})
+//	})
+//
+// With the neo4j+s and neo4j+ssc URI schemes, TLS connections to the resolved IP addresses indicate and are verified
+// against the host name the addresses have been resolved from.
+func NewDnsResolver(config DnsResolverConfig) ServerAddressResolverWithContext {
+	resolver := config.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	return &dnsResolver{srvService: config.SrvService, resolver: resolver}
+}
+
+type dnsResolver struct {
+	srvService string
+	resolver   *net.Resolver
+}
+
+func (r *dnsResolver) ResolveAddress(ctx context.Context, address ServerAddress) ([]ServerAddress, error) {
+	host := address.Hostname()
+	if net.ParseIP(host) != nil {
+		return []ServerAddress{address}, nil
+	}
+	if r.srvService != "" {
+		_, records, err := r.resolver.LookupSRV(ctx, r.srvService, "tcp", host)
+		var dnsErr *net.DNSError
+		if err != nil && !(errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			return nil, err
+		}
+		if len(records) > 0 {
+			var addresses []ServerAddress
+			for _, record := range records {
+				targetAddresses, err := r.lookupIP(ctx, strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port)))
+				if err != nil {
+					return nil, err
+				}
+				addresses = append(addresses, targetAddresses...)
+			}
+			return addresses, nil
+		}
+	}
+	return r.lookupIP(ctx, host, address.Port())
+}
+
+func (r *dnsResolver) lookupIP(ctx context.Context, host, port string) ([]ServerAddress, error) {
+	ips, err := r.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]ServerAddress, len(ips))
+	for i, ip := range ips {
+		// IPv6 addresses are enclosed in brackets
+		addresses[i] = &url.URL{Host: net.JoinHostPort(ip.String(), port)}
+	}
+	return addresses, nil
+}
+
+// Converts the resolver for the router, the host names of the resolved addresses are registered in serverNames
+func toRouterResolver(resolver ServerAddressResolverWithContext, serverNames *connector.ServerNames) router.Resolver {
+	return func(ctx context.Context, address string) ([]string, error) {
+		routerAddress := &url.URL{Host: address}
+		addresses, err := resolver.ResolveAddress(ctx, routerAddress)
+		if err != nil {
+			return nil, err
+		}
+		servers := make([]string, len(addresses))
+		for i, resolved := range addresses {
+			// Addresses resolved without port keep the port of the router
+			port := resolved.Port()
+			if port == "" {
+				port = routerAddress.Port()
+			}
+			servers[i] = net.JoinHostPort(resolved.Hostname(), port)
+			if resolved.Hostname() != routerAddress.Hostname() {
+				serverNames.Register(servers[i], routerAddress.Hostname())
+			}
+		}
+		return servers, nil
+	}
+}
@@ -0,0 +1,312 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/connector"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestDnsResolver(outer *testing.T) {
+	ctx := context.Background()
+	server := startStubDnsServer(outer, map[string][]stubDnsRecord{
+		"cluster.neo4j.test.":            {aRecord("10.0.0.1"), aRecord("10.0.0.2"), aaaaRecord("fd00::1")},
+		"core1.neo4j.test.":              {aRecord("10.0.1.1")},
+		"core2.neo4j.test.":              {aRecord("10.0.1.2"), aaaaRecord("fd00::2")},
+		"_bolt._tcp.cluster.neo4j.test.": {srvRecord(7688, "core1.neo4j.test."), srvRecord(7689, "core2.neo4j.test.")},
+	})
+	defer server.Close()
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			dialer := net.Dialer{}
+			return dialer.DialContext(ctx, "udp", server.LocalAddr().String())
+		},
+	}
+
+	outer.Run("expands A and AAAA records", func(t *testing.T) {
+		dnsResolver := NewDnsResolver(DnsResolverConfig{Resolver: resolver})
+
+		addresses, err := dnsResolver.ResolveAddress(ctx, NewServerAddress("cluster.neo4j.test", "7687"))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, joinAddresses(addresses), []string{"10.0.0.1:7687", "10.0.0.2:7687", "[fd00::1]:7687"})
+	})
+
+	outer.Run("expands SRV records", func(t *testing.T) {
+		dnsResolver := NewDnsResolver(DnsResolverConfig{Resolver: resolver, SrvService: "bolt"})
+
+		addresses, err := dnsResolver.ResolveAddress(ctx, NewServerAddress("cluster.neo4j.test", "7687"))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, joinAddresses(addresses), []string{"10.0.1.1:7688", "10.0.1.2:7689", "[fd00::2]:7689"})
+	})
+
+	outer.Run("expands A and AAAA records of host names without SRV records", func(t *testing.T) {
+		dnsResolver := NewDnsResolver(DnsResolverConfig{Resolver: resolver, SrvService: "bolt"})
+
+		addresses, err := dnsResolver.ResolveAddress(ctx, NewServerAddress("core1.neo4j.test", "7687"))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, joinAddresses(addresses), []string{"10.0.1.1:7687"})
+	})
+
+	outer.Run("leaves IP addresses as they are", func(t *testing.T) {
+		dnsResolver := NewDnsResolver(DnsResolverConfig{Resolver: resolver, SrvService: "bolt"})
+
+		addresses, err := dnsResolver.ResolveAddress(ctx, NewServerAddress("10.0.0.9", "7687"))
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, joinAddresses(addresses), []string{"10.0.0.9:7687"})
+	})
+
+	outer.Run("fails on unknown host names", func(t *testing.T) {
+		dnsResolver := NewDnsResolver(DnsResolverConfig{Resolver: resolver})
+
+		_, err := dnsResolver.ResolveAddress(ctx, NewServerAddress("unknown.neo4j.test", "7687"))
+
+		AssertError(t, err)
+	})
+}
+
+func TestRouterResolver(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("joins resolved host names and ports", func(t *testing.T) {
+		resolve := toRouterResolver(ServerAddressResolverWithContextFunc(
+			func(_ context.Context, address ServerAddress) ([]ServerAddress, error) {
+				AssertStringEqual(t, address.Hostname(), "cluster")
+				AssertStringEqual(t, address.Port(), "7687")
+				return []ServerAddress{NewServerAddress("10.0.0.1", "7688"), NewServerAddress("10.0.0.2", "")}, nil
+			}), connector.NewServerNames())
+
+		servers, err := resolve(ctx, "cluster:7687")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, servers, []string{"10.0.0.1:7688", "10.0.0.2:7687"})
+	})
+
+	outer.Run("encloses IPv6 addresses in brackets", func(t *testing.T) {
+		resolve := toRouterResolver(ServerAddressResolverWithContextFunc(
+			func(context.Context, ServerAddress) ([]ServerAddress, error) {
+				return []ServerAddress{NewServerAddress("[fd00::1]", "7687")}, nil
+			}), connector.NewServerNames())
+
+		servers, err := resolve(ctx, "cluster:7687")
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, servers, []string{"[fd00::1]:7687"})
+	})
+
+	outer.Run("registers the host names of resolved addresses", func(t *testing.T) {
+		serverNames := connector.NewServerNames()
+		resolve := toRouterResolver(ServerAddressResolverWithContextFunc(
+			func(_ context.Context, address ServerAddress) ([]ServerAddress, error) {
+				return []ServerAddress{NewServerAddress("10.0.0.1", "7687"), address}, nil
+			}), serverNames)
+
+		_, err := resolve(ctx, "cluster:7687")
+
+		AssertNoError(t, err)
+		host, found := serverNames.Lookup("10.0.0.1:7687")
+		AssertTrue(t, found)
+		AssertStringEqual(t, host, "cluster")
+		_, found = serverNames.Lookup("cluster:7687")
+		AssertFalse(t, found)
+	})
+}
+
+func TestDriverAddressResolverWithContext(outer *testing.T) {
+	ctx := context.Background()
+	withResolver := func(config *Config) {
+		config.AddressResolverWithContext = NewDnsResolver(DnsResolverConfig{})
+	}
+
+	outer.Run("resolves routers of unencrypted routing URL schemes", func(t *testing.T) {
+		driver, err := NewDriverWithContext("neo4j://localhost:7687", NoAuth(), withResolver)
+
+		AssertNoError(t, err)
+		AssertNoError(t, driver.Close(ctx))
+	})
+
+	for _, scheme := range []string{"neo4j+s", "neo4j+ssc"} {
+		outer.Run("indicates the host names of resolved routers with encrypted URL scheme "+scheme, func(t *testing.T) {
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			AssertNoError(t, err)
+			defer listener.Close()
+			serverNames := make(chan string, 1)
+			go func() {
+				conn, err := listener.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				// Records the server name indicated by the client and aborts the TLS handshake
+				tls.Server(conn, &tls.Config{GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+					serverNames <- hello.ServerName
+					return nil, errors.New("handshake aborted")
+				}}).Handshake()
+			}()
+			_, port, err := net.SplitHostPort(listener.Addr().String())
+			AssertNoError(t, err)
+			driver, err := NewDriverWithContext(scheme+"://cluster.example.com:"+port, NoAuth(), func(config *Config) {
+				config.AddressResolverWithContext = ServerAddressResolverWithContextFunc(
+					func(context.Context, ServerAddress) ([]ServerAddress, error) {
+						return []ServerAddress{NewServerAddress("127.0.0.1", port)}, nil
+					})
+			})
+			AssertNoError(t, err)
+			defer driver.Close(ctx)
+
+			AssertError(t, driver.VerifyConnectivity(ctx))
+
+			AssertStringEqual(t, <-serverNames, "cluster.example.com")
+		})
+	}
+}
+
+func joinAddresses(addresses []ServerAddress) []string {
+	result := make([]string, len(addresses))
+	for i, address := range addresses {
+		result[i] = net.JoinHostPort(address.Hostname(), address.Port())
+	}
+	sort.Strings(result)
+	return result
+}
+
+const (
+	dnsTypeA    = 1
+	dnsTypeAAAA = 28
+	dnsTypeSRV  = 33
+)
+
+type stubDnsRecord struct {
+	recordType uint16
+	data       []byte
+}
+
+func aRecord(ip string) stubDnsRecord {
+	return stubDnsRecord{recordType: dnsTypeA, data: net.ParseIP(ip).To4()}
+}
+
+func aaaaRecord(ip string) stubDnsRecord {
+	return stubDnsRecord{recordType: dnsTypeAAAA, data: net.ParseIP(ip).To16()}
+}
+
+func srvRecord(port uint16, target string) stubDnsRecord {
+	// Priority and weight, followed by port and target
+	data := []byte{0, 10, 0, 10}
+	data = appendUint16(data, port)
+	for _, label := range strings.Split(strings.TrimSuffix(target, "."), ".") {
+		data = append(data, byte(len(label)))
+		data = append(data, label...)
+	}
+	data = append(data, 0)
+	return stubDnsRecord{recordType: dnsTypeSRV, data: data}
+}
+
+// Starts a DNS server answering queries over UDP with the records of the zone, unknown names do not exist
+func startStubDnsServer(t *testing.T, zone map[string][]stubDnsRecord) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	AssertNoError(t, err)
+	go func() {
+		buffer := make([]byte, 1500)
+		for {
+			n, address, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			if response := answerDnsQuery(buffer[:n], zone); response != nil {
+				_, _ = conn.WriteTo(response, address)
+			}
+		}
+	}()
+	return conn
+}
+
+func answerDnsQuery(query []byte, zone map[string][]stubDnsRecord) []byte {
+	const headerLen = 12
+	if len(query) <= headerLen {
+		return nil
+	}
+	// The question follows the header: the name labels, then its type and class
+	var labels []string
+	offset := headerLen
+	for offset < len(query) && query[offset] != 0 {
+		labelLen := int(query[offset])
+		if offset+1+labelLen > len(query) {
+			return nil
+		}
+		labels = append(labels, string(query[offset+1:offset+1+labelLen]))
+		offset += 1 + labelLen
+	}
+	offset++
+	if offset+4 > len(query) {
+		return nil
+	}
+	questionType := binary.BigEndian.Uint16(query[offset:])
+	question := query[headerLen : offset+4]
+	records, found := zone[strings.ToLower(strings.Join(labels, "."))+"."]
+
+	var answers []stubDnsRecord
+	for _, record := range records {
+		if record.recordType == questionType {
+			answers = append(answers, record)
+		}
+	}
+	// Response with recursion desired and available, name error when the name is unknown
+	flags := uint16(0x8180)
+	if !found {
+		flags |= 3
+	}
+	response := append([]byte{}, query[0:2]...)
+	response = appendUint16(response, flags)
+	response = appendUint16(response, 1)
+	response = appendUint16(response, uint16(len(answers)))
+	response = append(response, 0, 0, 0, 0)
+	response = append(response, question...)
+	for _, answer := range answers {
+		// Name pointing to the question name, type, class IN, TTL and data
+		response = append(response, 0xc0, headerLen)
+		response = appendUint16(response, answer.recordType)
+		response = appendUint16(response, 1)
+		response = appendUint32(response, 60)
+		response = appendUint16(response, uint16(len(answer.data)))
+		response = append(response, answer.data...)
+	}
+	return response
+}
+
+func appendUint16(data []byte, value uint16) []byte {
+	return append(data, byte(value>>8), byte(value))
+}
+
+func appendUint32(data []byte, value uint32) []byte {
+	return append(appendUint16(data, uint16(value>>16)), byte(value>>8), byte(value))
+}
@@ -44,7 +44,10 @@ type (
 	Node          = dbtype.Node
 	Relationship  = dbtype.Relationship
 	Path          = dbtype.Path
+	Entity        = dbtype.Entity
 	Record        = db.Record
+
+	ValueMarshaler = dbtype.ValueMarshaler
 )
 
 // DateOf creates a neo4j.Date from time.Time.
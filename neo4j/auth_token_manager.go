@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// AuthTokenManager supplies the authentication tokens the driver uses to open and re-authenticate connections.
+// The driver calls GetAuthToken every time a connection is acquired, implementations are therefore expected to
+// cache tokens and to only fetch new ones when needed. Implementations must be thread safe.
+// Note that AuthToken implements AuthTokenManager and always supplies itself.
+// This API is experimental and may be changed or removed without prior notice
+type AuthTokenManager interface {
+	// GetAuthToken retrieves the current authentication token, or returns an error if the retrieval fails
+	GetAuthToken(ctx context.Context) (AuthToken, error)
+	// HandleSecurityException is called when the server reports a security error (Neo.ClientError.Security.*)
+	// on a connection authenticated with token.
+	// It returns true when the error has been handled, meaning that the next call to GetAuthToken supplies
+	// a fresh token. Work failing with Neo.ClientError.Security.TokenExpired is then retried by transaction
+	// functions.
+	HandleSecurityException(token AuthToken, securityException *Neo4jError) bool
+}
+
+// GetAuthToken makes AuthToken a static AuthTokenManager always supplying itself
+func (a AuthToken) GetAuthToken(context.Context) (AuthToken, error) {
+	return a, nil
+}
+
+// HandleSecurityException never handles any error since a static token cannot be refreshed
+func (a AuthToken) HandleSecurityException(AuthToken, *Neo4jError) bool {
+	return false
+}
+
+// BasicAuthTokenManager creates an AuthTokenManager for rotating basic credentials.
+// The provider is called lazily the first time a token is needed, and again after the server rejected the
+// current token with Neo.ClientError.Security.Unauthorized.
+// This API is experimental and may be changed or removed without prior notice
+func BasicAuthTokenManager(provider func(ctx context.Context) (AuthToken, error)) AuthTokenManager {
+	return &expirationBasedAuthTokenManager{
+		provider: func(ctx context.Context) (AuthToken, *time.Time, error) {
+			token, err := provider(ctx)
+			return token, nil, err
+		},
+		handledCodes: []string{"Neo.ClientError.Security.Unauthorized"},
+		now:          time.Now,
+	}
+}
+
+// BearerAuthTokenManager creates an AuthTokenManager for bearer tokens with an expiration time.
+// The provider returns the token along with its expiration time, nil meaning that the token never expires.
+// The provider is called lazily the first time a token is needed, once the token has expired, and again after
+// the server reported the current token as expired (Neo.ClientError.Security.TokenExpired) or rejected it
+// (Neo.ClientError.Security.Unauthorized).
+// This API is experimental and may be changed or removed without prior notice
+func BearerAuthTokenManager(provider func(ctx context.Context) (AuthToken, *time.Time, error)) AuthTokenManager {
+	return &expirationBasedAuthTokenManager{
+		provider: provider,
+		handledCodes: []string{
+			"Neo.ClientError.Security.TokenExpired",
+			"Neo.ClientError.Security.Unauthorized",
+		},
+		now: time.Now,
+	}
+}
+
+type expirationBasedAuthTokenManager struct {
+	provider     func(ctx context.Context) (AuthToken, *time.Time, error)
+	handledCodes []string
+	token        *AuthToken
+	expiration   *time.Time
+	mut          sync.Mutex
+	now          func() time.Time
+}
+
+func (m *expirationBasedAuthTokenManager) GetAuthToken(ctx context.Context) (AuthToken, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if m.token == nil || m.expiration != nil && !m.now().Before(*m.expiration) {
+		token, expiration, err := m.provider(ctx)
+		if err != nil {
+			return AuthToken{}, err
+		}
+		m.token = &token
+		m.expiration = expiration
+	}
+	return *m.token, nil
+}
+
+func (m *expirationBasedAuthTokenManager) HandleSecurityException(token AuthToken, securityException *Neo4jError) bool {
+	if !m.handles(securityException.Code) {
+		return false
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	// Only forget the current token if it is the one that failed, another thread may have already refreshed it
+	if m.token != nil && reflect.DeepEqual(m.token.tokens, token.tokens) {
+		m.token = nil
+		m.expiration = nil
+	}
+	return true
+}
+
+func (m *expirationBasedAuthTokenManager) handles(code string) bool {
+	for _, handledCode := range m.handledCodes {
+		if handledCode == code {
+			return true
+		}
+	}
+	return false
+}
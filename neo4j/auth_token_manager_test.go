@@ -0,0 +1,164 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestAuthTokenManagers(outer *testing.T) {
+	ctx := context.Background()
+	tokenExpiredErr := &Neo4jError{Code: "Neo.ClientError.Security.TokenExpired"}
+	unauthorizedErr := &Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"}
+
+	outer.Run("static tokens supply themselves and never handle errors", func(t *testing.T) {
+		token := BasicAuth("neo4j", "pass", "")
+
+		suppliedToken, err := token.GetAuthToken(ctx)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, suppliedToken, token)
+		AssertFalse(t, token.HandleSecurityException(token, unauthorizedErr))
+	})
+
+	outer.Run("basic", func(inner *testing.T) {
+		inner.Run("fetches token lazily and caches it", func(t *testing.T) {
+			calls := 0
+			manager := BasicAuthTokenManager(func(context.Context) (AuthToken, error) {
+				calls++
+				return BasicAuth("neo4j", "pass", ""), nil
+			})
+			AssertIntEqual(t, calls, 0)
+
+			token1, err1 := manager.GetAuthToken(ctx)
+			token2, err2 := manager.GetAuthToken(ctx)
+
+			AssertNoError(t, err1)
+			AssertNoError(t, err2)
+			AssertDeepEquals(t, token1, token2)
+			AssertIntEqual(t, calls, 1)
+		})
+
+		inner.Run("fetches a new token after unauthorized errors", func(t *testing.T) {
+			passwords := []string{"old", "new"}
+			calls := 0
+			manager := BasicAuthTokenManager(func(context.Context) (AuthToken, error) {
+				calls++
+				return BasicAuth("neo4j", passwords[calls-1], ""), nil
+			})
+			oldToken, _ := manager.GetAuthToken(ctx)
+
+			handled := manager.HandleSecurityException(oldToken, unauthorizedErr)
+			newToken, err := manager.GetAuthToken(ctx)
+
+			AssertTrue(t, handled)
+			AssertNoError(t, err)
+			AssertDeepEquals(t, newToken, BasicAuth("neo4j", "new", ""))
+		})
+
+		inner.Run("does not handle expired tokens", func(t *testing.T) {
+			manager := BasicAuthTokenManager(func(context.Context) (AuthToken, error) {
+				return BasicAuth("neo4j", "pass", ""), nil
+			})
+			token, _ := manager.GetAuthToken(ctx)
+
+			AssertFalse(t, manager.HandleSecurityException(token, tokenExpiredErr))
+		})
+
+		inner.Run("returns provider errors", func(t *testing.T) {
+			providerErr := errors.New("oopsie")
+			manager := BasicAuthTokenManager(func(context.Context) (AuthToken, error) {
+				return AuthToken{}, providerErr
+			})
+
+			_, err := manager.GetAuthToken(ctx)
+
+			AssertDeepEquals(t, err, providerErr)
+		})
+	})
+
+	outer.Run("bearer", func(inner *testing.T) {
+		now := time.Now()
+		newBearerManager := func(tokens ...string) (*expirationBasedAuthTokenManager, *int) {
+			calls := 0
+			expiration := now.Add(time.Minute)
+			manager := BearerAuthTokenManager(func(context.Context) (AuthToken, *time.Time, error) {
+				calls++
+				return BearerAuth(tokens[calls-1]), &expiration, nil
+			}).(*expirationBasedAuthTokenManager)
+			manager.now = func() time.Time { return now }
+			return manager, &calls
+		}
+
+		inner.Run("caches token until expiration", func(t *testing.T) {
+			manager, calls := newBearerManager("token1", "token2")
+
+			token1, _ := manager.GetAuthToken(ctx)
+			manager.now = func() time.Time { return now.Add(30 * time.Second) }
+			token2, _ := manager.GetAuthToken(ctx)
+			manager.now = func() time.Time { return now.Add(time.Minute) }
+			token3, _ := manager.GetAuthToken(ctx)
+
+			AssertDeepEquals(t, token1, BearerAuth("token1"))
+			AssertDeepEquals(t, token2, BearerAuth("token1"))
+			AssertDeepEquals(t, token3, BearerAuth("token2"))
+			AssertIntEqual(t, *calls, 2)
+		})
+
+		inner.Run("fetches a new token after expired token errors", func(t *testing.T) {
+			manager, _ := newBearerManager("token1", "token2")
+			token1, _ := manager.GetAuthToken(ctx)
+
+			handled := manager.HandleSecurityException(token1, tokenExpiredErr)
+			token2, _ := manager.GetAuthToken(ctx)
+
+			AssertTrue(t, handled)
+			AssertDeepEquals(t, token2, BearerAuth("token2"))
+		})
+
+		inner.Run("keeps newer token when an outdated token fails", func(t *testing.T) {
+			manager, calls := newBearerManager("token1", "token2")
+			token1, _ := manager.GetAuthToken(ctx)
+			manager.HandleSecurityException(token1, tokenExpiredErr)
+			_, _ = manager.GetAuthToken(ctx)
+
+			handled := manager.HandleSecurityException(token1, tokenExpiredErr)
+			token, _ := manager.GetAuthToken(ctx)
+
+			AssertTrue(t, handled)
+			AssertDeepEquals(t, token, BearerAuth("token2"))
+			AssertIntEqual(t, *calls, 2)
+		})
+
+		inner.Run("does not handle other security errors", func(t *testing.T) {
+			manager, _ := newBearerManager("token1")
+			token, _ := manager.GetAuthToken(ctx)
+
+			handled := manager.HandleSecurityException(token, &Neo4jError{Code: "Neo.ClientError.Security.Forbidden"})
+
+			AssertFalse(t, handled)
+		})
+	})
+}
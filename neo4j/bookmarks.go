@@ -1,5 +1,10 @@
 package neo4j
 
+import (
+	"context"
+	"sync"
+)
+
 /*
 Bookmarks is a holder for server-side bookmarks which are used for causally chaining sessions.
 
@@ -55,3 +60,191 @@ See Session.LastBookmarks and CombineBookmarks for alternatives.
 func BookmarksFromRawValues(values ...string) Bookmarks {
 	return values
 }
+
+// BookmarkManager centralizes bookmark supply and notification
+// This API is experimental and may be changed or removed without prior notice
+type BookmarkManager interface {
+	// UpdateBookmarks updates the bookmarks for the specified database
+	// previousBookmarks are the initial bookmarks of the bookmark holder (like a Session)
+	// newBookmarks are the bookmarks that are received after completion of the bookmark holder operation (like the end of a Session)
+	UpdateBookmarks(ctx context.Context, database string, previousBookmarks, newBookmarks Bookmarks) error
+
+	// GetAllBookmarks returns all the bookmarks tracked by this bookmark manager
+	// Note: the order of the returned bookmark slice does not need to be deterministic
+	GetAllBookmarks(ctx context.Context) (Bookmarks, error)
+
+	// GetBookmarks returns all the bookmarks associated with the specified database
+	// Note: the order of the returned bookmark slice does not need to be deterministic
+	GetBookmarks(ctx context.Context, database string) (Bookmarks, error)
+
+	// Forget removes the bookmarks of the specified databases
+	// Calling Forget without any database does not remove anything
+	// Note: it is the driver user's responsibility to call this
+	Forget(ctx context.Context, databases ...string) error
+}
+
+// BookmarkSupplier supplies bookmarks coming from an external source, such as a store shared by
+// several application instances
+type BookmarkSupplier interface {
+	// GetAllBookmarks returns all known bookmarks
+	GetAllBookmarks(ctx context.Context) (Bookmarks, error)
+
+	// GetBookmarks returns all the bookmarks of the specified database
+	GetBookmarks(ctx context.Context, database string) (Bookmarks, error)
+}
+
+// BookmarkManagerConfig holds the configuration of the default BookmarkManager implementation returned by
+// NewBookmarkManager
+type BookmarkManagerConfig struct {
+	// Initial bookmarks per database
+	InitialBookmarks map[string]Bookmarks
+
+	// Supplier providing external bookmarks
+	BookmarkSupplier BookmarkSupplier
+
+	// Hook called whenever bookmarks for a given database get updated
+	// The hook is called with the database and the new bookmarks
+	// Note: the order of the supplied bookmark slice is not guaranteed
+	BookmarkConsumer func(ctx context.Context, database string, bookmarks Bookmarks) error
+}
+
+type bookmarkManager struct {
+	bookmarks        map[string]map[string]struct{}
+	bookmarkSupplier BookmarkSupplier
+	bookmarkConsumer func(context.Context, string, Bookmarks) error
+	mutex            sync.RWMutex
+}
+
+// NewBookmarkManager creates the default, in-memory BookmarkManager implementation
+// This API is experimental and may be changed or removed without prior notice
+func NewBookmarkManager(config BookmarkManagerConfig) BookmarkManager {
+	bookmarks := make(map[string]map[string]struct{}, len(config.InitialBookmarks))
+	for db, initialBookmarks := range config.InitialBookmarks {
+		bookmarks[db] = newBookmarkSet(initialBookmarks)
+	}
+	return &bookmarkManager{
+		bookmarks:        bookmarks,
+		bookmarkSupplier: config.BookmarkSupplier,
+		bookmarkConsumer: config.BookmarkConsumer,
+	}
+}
+
+func (b *bookmarkManager) UpdateBookmarks(ctx context.Context, database string, previousBookmarks, newBookmarks Bookmarks) error {
+	if len(newBookmarks) == 0 {
+		return nil
+	}
+	var bookmarksToNotify Bookmarks
+	b.mutex.Lock()
+	bookmarks, found := b.bookmarks[database]
+	if !found {
+		bookmarks = make(map[string]struct{}, len(newBookmarks))
+		b.bookmarks[database] = bookmarks
+	}
+	for _, bookmark := range previousBookmarks {
+		delete(bookmarks, bookmark)
+	}
+	for _, bookmark := range newBookmarks {
+		bookmarks[bookmark] = struct{}{}
+	}
+	if b.bookmarkConsumer != nil {
+		bookmarksToNotify = bookmarkSetValues(bookmarks)
+	}
+	b.mutex.Unlock()
+	if bookmarksToNotify != nil {
+		return b.bookmarkConsumer(ctx, database, bookmarksToNotify)
+	}
+	return nil
+}
+
+func (b *bookmarkManager) GetAllBookmarks(ctx context.Context) (Bookmarks, error) {
+	allBookmarks := make(map[string]struct{})
+	b.mutex.RLock()
+	for _, bookmarks := range b.bookmarks {
+		for bookmark := range bookmarks {
+			allBookmarks[bookmark] = struct{}{}
+		}
+	}
+	b.mutex.RUnlock()
+	if b.bookmarkSupplier != nil {
+		extraBookmarks, err := b.bookmarkSupplier.GetAllBookmarks(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, bookmark := range extraBookmarks {
+			allBookmarks[bookmark] = struct{}{}
+		}
+	}
+	return bookmarkSetValues(allBookmarks), nil
+}
+
+func (b *bookmarkManager) GetBookmarks(ctx context.Context, database string) (Bookmarks, error) {
+	var extraBookmarks Bookmarks
+	if b.bookmarkSupplier != nil {
+		var err error
+		if extraBookmarks, err = b.bookmarkSupplier.GetBookmarks(ctx, database); err != nil {
+			return nil, err
+		}
+	}
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	bookmarks, found := b.bookmarks[database]
+	if !found && len(extraBookmarks) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]struct{}, len(bookmarks)+len(extraBookmarks))
+	for bookmark := range bookmarks {
+		result[bookmark] = struct{}{}
+	}
+	for _, bookmark := range extraBookmarks {
+		result[bookmark] = struct{}{}
+	}
+	return bookmarkSetValues(result), nil
+}
+
+func (b *bookmarkManager) Forget(_ context.Context, databases ...string) error {
+	if len(databases) == 0 {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for _, db := range databases {
+		delete(b.bookmarks, db)
+	}
+	return nil
+}
+
+func newBookmarkSet(bookmarks Bookmarks) map[string]struct{} {
+	set := make(map[string]struct{}, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		set[bookmark] = struct{}{}
+	}
+	return set
+}
+
+func bookmarkSetValues(set map[string]struct{}) Bookmarks {
+	values := make(Bookmarks, 0, len(set))
+	for bookmark := range set {
+		values = append(values, bookmark)
+	}
+	return values
+}
+
+// Combines the given bookmarks, removing duplicates while preserving the order of first occurrence
+func combineUniqueBookmarks(bookmarks ...Bookmarks) Bookmarks {
+	var lenSum int
+	for _, b := range bookmarks {
+		lenSum += len(b)
+	}
+	seen := make(map[string]struct{}, lenSum)
+	result := make(Bookmarks, 0, lenSum)
+	for _, b := range bookmarks {
+		for _, bookmark := range b {
+			if _, found := seen[bookmark]; found {
+				continue
+			}
+			seen[bookmark] = struct{}{}
+			result = append(result, bookmark)
+		}
+	}
+	return result
+}
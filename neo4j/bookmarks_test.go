@@ -1,8 +1,13 @@
 package neo4j
 
 import (
+	"context"
+	"errors"
+	"sort"
 	"testing"
 	"testing/quick"
+
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 )
 
 func TestCombineBookmarks(t *testing.T) {
@@ -30,3 +35,184 @@ func TestCombineBookmarks(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestBookmarkManager(outer *testing.T) {
+	ctx := context.Background()
+
+	sorted := func(bookmarks Bookmarks) Bookmarks {
+		sort.Strings(bookmarks)
+		return bookmarks
+	}
+
+	outer.Run("returns initial bookmarks per database", func(t *testing.T) {
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+			"db1": {"a", "b"},
+			"db2": {"c"},
+		}})
+
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx, "db1")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, sorted(bookmarks), Bookmarks{"a", "b"})
+		allBookmarks, err := bookmarkManager.GetAllBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, sorted(allBookmarks), Bookmarks{"a", "b", "c"})
+	})
+
+	outer.Run("returns no bookmarks for unknown database", func(t *testing.T) {
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{})
+
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx, "db1")
+
+		AssertNoError(t, err)
+		AssertLen(t, bookmarks, 0)
+	})
+
+	outer.Run("replaces previous bookmarks with new ones", func(t *testing.T) {
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+			"db1": {"a", "b", "c"},
+		}})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, "db1", Bookmarks{"a", "b"}, Bookmarks{"d"})
+
+		AssertNoError(t, err)
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx, "db1")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, sorted(bookmarks), Bookmarks{"c", "d"})
+	})
+
+	outer.Run("ignores updates without new bookmarks", func(t *testing.T) {
+		consumerCalled := false
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+			InitialBookmarks: map[string]Bookmarks{"db1": {"a"}},
+			BookmarkConsumer: func(context.Context, string, Bookmarks) error {
+				consumerCalled = true
+				return nil
+			},
+		})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, "db1", Bookmarks{"a"}, nil)
+
+		AssertNoError(t, err)
+		AssertFalse(t, consumerCalled)
+		bookmarks, _ := bookmarkManager.GetBookmarks(ctx, "db1")
+		AssertDeepEquals(t, bookmarks, Bookmarks{"a"})
+	})
+
+	outer.Run("notifies consumer of updated database bookmarks", func(t *testing.T) {
+		var notifiedDatabase string
+		var notifiedBookmarks Bookmarks
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+			InitialBookmarks: map[string]Bookmarks{"db1": {"a", "b"}},
+			BookmarkConsumer: func(_ context.Context, database string, bookmarks Bookmarks) error {
+				notifiedDatabase = database
+				notifiedBookmarks = bookmarks
+				return nil
+			},
+		})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, "db1", Bookmarks{"a"}, Bookmarks{"c"})
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, notifiedDatabase, "db1")
+		AssertDeepEquals(t, sorted(notifiedBookmarks), Bookmarks{"b", "c"})
+	})
+
+	outer.Run("returns consumer errors", func(t *testing.T) {
+		consumerErr := errors.New("oopsie")
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+			BookmarkConsumer: func(context.Context, string, Bookmarks) error {
+				return consumerErr
+			},
+		})
+
+		err := bookmarkManager.UpdateBookmarks(ctx, "db1", nil, Bookmarks{"a"})
+
+		AssertDeepEquals(t, err, consumerErr)
+	})
+
+	outer.Run("includes supplied bookmarks", func(t *testing.T) {
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+			InitialBookmarks: map[string]Bookmarks{"db1": {"a"}, "db2": {"b"}},
+			BookmarkSupplier: &bookmarkSupplierFake{
+				bookmarks: map[string]Bookmarks{"db1": {"a", "c"}, "db3": {"d"}},
+			},
+		})
+
+		bookmarks, err := bookmarkManager.GetBookmarks(ctx, "db1")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, sorted(bookmarks), Bookmarks{"a", "c"})
+		allBookmarks, err := bookmarkManager.GetAllBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, sorted(allBookmarks), Bookmarks{"a", "b", "c", "d"})
+	})
+
+	outer.Run("returns supplier errors", func(t *testing.T) {
+		supplierErr := errors.New("oopsie")
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+			BookmarkSupplier: &bookmarkSupplierFake{err: supplierErr},
+		})
+
+		_, err := bookmarkManager.GetBookmarks(ctx, "db1")
+		AssertDeepEquals(t, err, supplierErr)
+		_, err = bookmarkManager.GetAllBookmarks(ctx)
+		AssertDeepEquals(t, err, supplierErr)
+	})
+
+	outer.Run("forgets databases", func(t *testing.T) {
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+			"db1": {"a"},
+			"db2": {"b"},
+			"db3": {"c"},
+		}})
+
+		err := bookmarkManager.Forget(ctx, "db1", "db3")
+
+		AssertNoError(t, err)
+		allBookmarks, err := bookmarkManager.GetAllBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, allBookmarks, Bookmarks{"b"})
+	})
+
+	outer.Run("forgets nothing without databases", func(t *testing.T) {
+		bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+			"db1": {"a"},
+			"db2": {"b"},
+		}})
+
+		err := bookmarkManager.Forget(ctx)
+
+		AssertNoError(t, err)
+		allBookmarks, err := bookmarkManager.GetAllBookmarks(ctx)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, sorted(allBookmarks), Bookmarks{"a", "b"})
+		db1Bookmarks, err := bookmarkManager.GetBookmarks(ctx, "db1")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, db1Bookmarks, Bookmarks{"a"})
+		db2Bookmarks, err := bookmarkManager.GetBookmarks(ctx, "db2")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, db2Bookmarks, Bookmarks{"b"})
+	})
+}
+
+type bookmarkSupplierFake struct {
+	bookmarks map[string]Bookmarks
+	err       error
+}
+
+func (b *bookmarkSupplierFake) GetAllBookmarks(context.Context) (Bookmarks, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	var result Bookmarks
+	for _, bookmarks := range b.bookmarks {
+		result = append(result, bookmarks...)
+	}
+	return result, nil
+}
+
+func (b *bookmarkSupplierFake) GetBookmarks(_ context.Context, database string) (Bookmarks, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.bookmarks[database], nil
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package codec lets applications register conversions between their own Go types and the
+// values the driver sends to and receives from the server.
+package codec
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type conversion func(interface{}) (interface{}, error)
+
+// Registry holds custom encoders and decoders, it is configured with neo4j.Config.Codecs.
+// Codecs should be registered before the driver is created, the registry is safe for concurrent use.
+type Registry struct {
+	mut      sync.RWMutex
+	encoders map[reflect.Type]conversion
+	decoders map[reflect.Type]conversion
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		encoders: map[reflect.Type]conversion{},
+		decoders: map[reflect.Type]conversion{},
+	}
+}
+
+// RegisterEncoder registers the function encoding query parameters of type T.
+// T must be a concrete type, values are matched against it by their exact type.
+// The function must return a value of a type the driver is able to send, other than T:
+//
+//	codec.RegisterEncoder(registry, func(id uuid.UUID) (interface{}, error) {
+//		return id.String(), nil
+//	})
+//
+// Encoders take precedence over the default encoding of the type, including dbtype.ValueMarshaler.
+// Registering an encoder for a type replaces any previously registered encoder for that type.
+func RegisterEncoder[T any](registry *Registry, encoder func(T) (interface{}, error)) {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+	registry.encoders[reflect.TypeOf((*T)(nil)).Elem()] = func(value interface{}) (interface{}, error) {
+		return encoder(value.(T))
+	}
+}
+
+// RegisterDecoder registers the function converting values of type T received from the server.
+// T is the type the driver represents a Cypher type with, such as string, int64 or dbtype.Duration.
+// Decoders apply to record values, including the elements of lists and maps, but not to
+// the properties of nodes and relationships:
+//
+//	codec.RegisterDecoder(registry, func(duration dbtype.Duration) (interface{}, error) {
+//		if duration.Months != 0 || duration.Days != 0 {
+//			return duration, nil
+//		}
+//		return time.Duration(duration.Seconds)*time.Second + time.Duration(duration.Nanos), nil
+//	})
+//
+// Registering a decoder for a type replaces any previously registered decoder for that type.
+func RegisterDecoder[T any](registry *Registry, decoder func(T) (interface{}, error)) {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+	registry.decoders[reflect.TypeOf((*T)(nil)).Elem()] = func(value interface{}) (interface{}, error) {
+		return decoder(value.(T))
+	}
+}
+
+// Encode returns the encoded value and true when an encoder is registered for the type of value.
+// It returns false when no encoder is registered, in which case the driver encodes the value itself.
+func (r *Registry) Encode(value interface{}) (interface{}, bool, error) {
+	encoder := r.lookup(r.encoders, value)
+	if encoder == nil {
+		return nil, false, nil
+	}
+	encoded, err := encoder(value)
+	if err != nil {
+		return nil, true, &Error{Type: reflect.TypeOf(value), Operation: "encode", Err: err}
+	}
+	return encoded, true, nil
+}
+
+// Decode returns the decoded value, or value itself when no decoder is registered for its type
+func (r *Registry) Decode(value interface{}) (interface{}, error) {
+	decoder := r.lookup(r.decoders, value)
+	if decoder == nil {
+		return value, nil
+	}
+	decoded, err := decoder(value)
+	if err != nil {
+		return nil, &Error{Type: reflect.TypeOf(value), Operation: "decode", Err: err}
+	}
+	return decoded, nil
+}
+
+// HasDecoders returns true when at least one decoder is registered
+func (r *Registry) HasDecoders() bool {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	return len(r.decoders) > 0
+}
+
+func (r *Registry) lookup(conversions map[reflect.Type]conversion, value interface{}) conversion {
+	if value == nil {
+		return nil
+	}
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+	return conversions[reflect.TypeOf(value)]
+}
+
+// Error is returned when a registered encoder or decoder fails
+type Error struct {
+	// Type is the type of the value that failed to be converted
+	Type reflect.Type
+	// Operation is either "encode" or "decode"
+	Operation string
+	// Err is the error returned by the encoder or decoder
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("failed to %s value of type %s: %s", e.Operation, e.Type, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
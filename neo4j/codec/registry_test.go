@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package codec
+
+import (
+	"errors"
+	"testing"
+)
+
+type celsius float64
+
+func TestRegistry(outer *testing.T) {
+	outer.Run("encodes values of registered types only", func(t *testing.T) {
+		registry := NewRegistry()
+		RegisterEncoder(registry, func(c celsius) (interface{}, error) {
+			return float64(c) + 273.15, nil
+		})
+
+		encoded, found, err := registry.Encode(celsius(10))
+		if err != nil || !found || encoded != 283.15 {
+			t.Errorf("expected 283.15 to be encoded, got %v, %v, %v", encoded, found, err)
+		}
+		_, found, err = registry.Encode(10.0)
+		if err != nil || found {
+			t.Errorf("expected float64 not to be encoded, got %v, %v", found, err)
+		}
+	})
+
+	outer.Run("decodes values of registered types only", func(t *testing.T) {
+		registry := NewRegistry()
+		RegisterDecoder(registry, func(f float64) (interface{}, error) {
+			return celsius(f - 273.15), nil
+		})
+
+		decoded, err := registry.Decode(273.15)
+		if err != nil || decoded != celsius(0) {
+			t.Errorf("expected celsius(0), got %v, %v", decoded, err)
+		}
+		decoded, err = registry.Decode("hot")
+		if err != nil || decoded != "hot" {
+			t.Errorf("expected string to be returned as is, got %v, %v", decoded, err)
+		}
+		if !registry.HasDecoders() {
+			t.Errorf("expected registry to have decoders")
+		}
+	})
+
+	outer.Run("wraps conversion errors", func(t *testing.T) {
+		registry := NewRegistry()
+		conversionErr := errors.New("too cold")
+		RegisterEncoder(registry, func(c celsius) (interface{}, error) {
+			return nil, conversionErr
+		})
+
+		_, found, err := registry.Encode(celsius(-300))
+
+		var codecErr *Error
+		if !found || !errors.As(err, &codecErr) || codecErr.Operation != "encode" || !errors.Is(err, conversionErr) {
+			t.Errorf("expected wrapped encoding error, got %v, %v", found, err)
+		}
+	})
+}
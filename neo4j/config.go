@@ -26,7 +26,10 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
 )
 
 // A Config contains options that can be used to customize certain
@@ -58,6 +61,8 @@ type Config struct {
 	//
 	// Possible to use custom logger (implement log.Logger interface) or
 	// use neo4j.ConsoleLogger.
+	// For structured logging, implement log.StructuredLogger and wrap it with log.Structured
+	// or, from Go 1.21, forward the entries to a log/slog handler with log.Slog.
 	//
 	// default: No Op Logger (log.Void)
 	Log log.Logger
@@ -68,6 +73,14 @@ type Config struct {
 	//
 	// default: nil
 	AddressResolver ServerAddressResolver
+	// AddressResolverWithContext resolves the address of every router into the addresses of the servers to
+	// connect to: the initial router as well as the routers of the routing tables.
+	// Unlike AddressResolver, it is applied before trying to connect to the routers, not as a last resort.
+	// Use NewDnsResolver to resolve the routers with DNS A, AAAA and SRV records.
+	// It cannot be set along with AddressResolver.
+	//
+	// default: nil
+	AddressResolverWithContext ServerAddressResolverWithContext
 	// Maximum amount of time a retryable operation would continue retrying. It
 	// cannot be specified as a negative value.
 	//
@@ -79,11 +92,68 @@ type Config struct {
 	//
 	// default: 100
 	MaxConnectionPoolSize int
+	// MinIdleConnectionsPerServer is the number of idle connections kept open to each of the servers
+	// the driver has been warmed up against, see DriverWithContext.WarmUp.
+	// Idle connections evicted from the pool, for instance because they exceeded MaxConnectionLifetime,
+	// are replenished in the background, within ConnectionAcquisitionTimeout per server.
+	// It cannot be negative nor exceed MaxConnectionPoolSize, 0 disables the replenishment.
+	//
+	// default: 0
+	MinIdleConnectionsPerServer int
 	// Maximum connection lifetime on pooled connections. Values less than
 	// or equal to 0 disables the lifetime check.
 	//
 	// default: 1 * time.Hour
 	MaxConnectionLifetime time.Duration
+	// Maximum amount of time pooled connections can stay idle before being closed.
+	// Use it to close idle connections before they are silently dropped by firewalls.
+	// Values less than or equal to 0 disables the idle time check.
+	//
+	// default: 0
+	MaxConnectionIdleTime time.Duration
+	// Interval at which a background goroutine maintains the pooled connections, instead of only checking them
+	// when they are borrowed. On every run, it:
+	//   - closes idle connections exceeding MaxConnectionLifetime or MaxConnectionIdleTime
+	//   - pings the connections idle for longer than the interval with a RESET message, which keeps them
+	//     from being dropped by firewalls and closes the ones that turn out to be dead
+	//   - closes the idle connections to servers that are no longer part of any routing table
+	//
+	// Values less than or equal to 0 disables the background maintenance.
+	//
+	// default: 0
+	ConnectionHousekeepingInterval time.Duration
+	// Connections that have been idle in the pool for longer than this timeout are verified with a RESET
+	// round trip before being handed out. Dead connections are closed and another connection is tried.
+	// Use it when connections are silently dropped by firewalls or load balancers cutting off idle connections.
+	//
+	// 0 verifies every idle connection before it is used, negative values disable the liveness check.
+	//
+	// default: math.MaxInt64 (disabled)
+	ConnectionLivenessCheckTimeout time.Duration
+	// RoutingPolicy is the name of the server-side routing policy the routing tables are requested for, such as
+	// a policy restricting reads to the servers of the local region. Policies are defined in the server
+	// configuration, the policy is sent to the server as the "policy" key of the routing context.
+	// The policy can be overridden per session with SessionConfig.RoutingPolicy.
+	//
+	// Routing policies require a neo4j URI scheme, the policy can also be set with the policy query parameter
+	// of the URI, setting both to different values is an error.
+	//
+	// default: "" (server default policy)
+	RoutingPolicy string
+	// LoadBalancingStrategy selects the server each connection is borrowed from, among the readers or the
+	// writers of the database when routing. Built-in strategies are LeastConnectedStrategy, RoundRobinStrategy
+	// and LatencyWeightedStrategy.
+	//
+	// default: nil (least connected servers, with recently failed servers and connecting costs taken into account)
+	LoadBalancingStrategy LoadBalancingStrategy
+	// RoutingTableListener is notified whenever the servers of a routing table change: when a new routing
+	// table is read, when a reader or a writer the driver failed to use is removed from the routing table and
+	// when an expired routing table is removed. Refreshes leaving the servers unchanged are not notified.
+	// Use it to alert on leader changes or to correlate errors with topology changes.
+	// The listener is called synchronously by the sessions changing the routing tables, so it must not block.
+	//
+	// default: nil
+	RoutingTableListener RoutingTableListener
 	// Maximum amount of time to either acquire an idle connection from the pool
 	// or create a new connection (when the pool is not full). Negative values
 	// result in an infinite wait time, whereas a 0 value results in no timeout.
@@ -139,20 +209,113 @@ type Config struct {
 	// If a single large result is to be retrieved, this is the most performant
 	// setting.
 	FetchSize int
+	// Tracer receives a span for every round trip with the server: transaction begins, query runs,
+	// record pulls, commits and rollbacks, as well as for every retry of transaction functions.
+	//
+	// Possible to use custom tracer (implement tracing.Tracer interface), for example
+	// to bridge the driver with OpenTelemetry.
+	//
+	// default: No Op Tracer (tracing.Void)
+	Tracer tracing.Tracer
+	// RedactQueriesInTraces removes the query text from the attributes of the spans sent to Tracer.
+	// Enable this setting when queries may embed sensitive literals.
+	//
+	// default: false
+	RedactQueriesInTraces bool
+	// NotificationsMinSeverity defines the minimum severity level of the notifications the server sends.
+	// Set it to NotificationMinSeverityOff to disable notifications altogether, which also spares
+	// the server the work of generating them.
+	//
+	// Notification filtering requires Bolt 5.2 (Neo4j 5.7) or later, connecting to older servers
+	// fails when a non-default value is configured.
+	// The setting can be overridden per session with SessionConfig.NotificationsMinSeverity.
+	//
+	// default: NotificationMinSeverityDefault (server default)
+	NotificationsMinSeverity NotificationMinSeverityLevel
+	// NotificationsDisabledCategories defines the categories of the notifications the server does not send.
+	// A nil slice keeps the server default, whereas an empty non-nil slice enables all categories.
+	//
+	// Notification filtering requires Bolt 5.2 (Neo4j 5.7) or later, connecting to older servers
+	// fails when a non-default value is configured.
+	// The setting can be overridden per session with SessionConfig.NotificationsDisabledCategories.
+	//
+	// default: nil (server default)
+	NotificationsDisabledCategories []NotificationCategory
+	// TelemetryDisabled stops the driver from reporting which of its APIs run transactions.
+	// Telemetry is only ever sent to servers that request it, from Bolt 5.4 onwards.
+	// The reported data is anonymous and only identifies the API, not the query.
+	//
+	// default: false
+	TelemetryDisabled bool
+	// Codecs registers custom conversions of query parameters and record values, see codec.Registry.
+	// Use it to send and receive application types, such as identifiers or decimals, without
+	// converting them at every call site:
+	//
+	//	registry := codec.NewRegistry()
+	//	codec.RegisterEncoder(registry, func(id uuid.UUID) (interface{}, error) {
+	//		return id.String(), nil
+	//	})
+	//	driver, err := neo4j.NewDriverWithContext(uri, auth, func(config *neo4j.Config) {
+	//		config.Codecs = registry
+	//	})
+	//
+	// default: nil (no custom conversions)
+	Codecs *codec.Registry
+	// LazyRecordDecoding keeps records in their raw form and decodes their values when accessed.
+	// It cuts down allocations when only some of the values of wide records are used.
+	// Values of lazily decoded records are accessed with Record.Get and Record.AllValues,
+	// their Values field is nil: code reading Record.Values directly breaks when enabling it.
+	//
+	// Values that cannot be decoded are nil and their error is reported by Record.Err. It also fails the
+	// result once accessed: ResultWithContext.Next returns false and ResultWithContext.Err returns the error.
+	// Records returned by ResultWithContext.Collect or ResultWithContext.Single must be checked with Record.Err,
+	// RecordAs and GetRecordValue report the error themselves.
+	//
+	// Lazy decoding is disabled on connections with a bolt logger and when Codecs has decoders.
+	//
+	// default: false
+	LazyRecordDecoding bool
+}
+
+// NotificationMinSeverityLevel is the minimum severity level of the notifications the server sends
+type NotificationMinSeverityLevel string
+
+const (
+	// NotificationMinSeverityDefault leaves the minimum severity level to the server default, or to the driver
+	// configuration when used in the session configuration
+	NotificationMinSeverityDefault NotificationMinSeverityLevel = ""
+	// NotificationMinSeverityOff disables all notifications
+	NotificationMinSeverityOff NotificationMinSeverityLevel = "OFF"
+	// NotificationMinSeverityWarning only enables warning notifications
+	NotificationMinSeverityWarning NotificationMinSeverityLevel = "WARNING"
+	// NotificationMinSeverityInformation enables both warning and information notifications
+	NotificationMinSeverityInformation NotificationMinSeverityLevel = "INFORMATION"
+)
+
+func toNotificationConfig(minSeverity NotificationMinSeverityLevel, disabledCategories []NotificationCategory) idb.NotificationConfig {
+	var categories []string
+	if disabledCategories != nil {
+		categories = make([]string, len(disabledCategories))
+		for i, category := range disabledCategories {
+			categories[i] = string(category)
+		}
+	}
+	return idb.NotificationConfig{MinSeverity: string(minSeverity), DisabledCategories: categories}
 }
 
 func defaultConfig() *Config {
 	return &Config{
-		AddressResolver:              nil,
-		MaxTransactionRetryTime:      30 * time.Second,
-		MaxConnectionPoolSize:        100,
-		MaxConnectionLifetime:        1 * time.Hour,
-		ConnectionAcquisitionTimeout: 1 * time.Minute,
-		SocketConnectTimeout:         5 * time.Second,
-		SocketKeepalive:              true,
-		RootCAs:                      nil,
-		UserAgent:                    UserAgent,
-		FetchSize:                    FetchDefault,
+		AddressResolver:                nil,
+		MaxTransactionRetryTime:        30 * time.Second,
+		MaxConnectionPoolSize:          100,
+		MaxConnectionLifetime:          1 * time.Hour,
+		ConnectionLivenessCheckTimeout: math.MaxInt64,
+		ConnectionAcquisitionTimeout:   1 * time.Minute,
+		SocketConnectTimeout:           5 * time.Second,
+		SocketKeepalive:                true,
+		RootCAs:                        nil,
+		UserAgent:                      UserAgent,
+		FetchSize:                      FetchDefault,
 	}
 }
 
@@ -162,6 +325,11 @@ func validateAndNormaliseConfig(config *Config) error {
 		return &UsageError{Message: "Maximum transaction retry time cannot be smaller than 0"}
 	}
 
+	// Address Resolvers
+	if config.AddressResolver != nil && config.AddressResolverWithContext != nil {
+		return &UsageError{Message: "Address resolver and address resolver with context cannot both be set"}
+	}
+
 	// Max Connection Pool Size
 	if config.MaxConnectionPoolSize == 0 {
 		return &UsageError{Message: "Maximum connection pool cannot be 0"}
@@ -171,11 +339,29 @@ func validateAndNormaliseConfig(config *Config) error {
 		config.MaxConnectionPoolSize = math.MaxInt32
 	}
 
+	// Min Idle Connections Per Server
+	if config.MinIdleConnectionsPerServer < 0 {
+		return &UsageError{Message: "Minimum idle connections per server cannot be smaller than 0"}
+	}
+	if config.MinIdleConnectionsPerServer > config.MaxConnectionPoolSize {
+		return &UsageError{Message: "Minimum idle connections per server cannot exceed maximum connection pool size"}
+	}
+
 	// Max Connection Lifetime
 	if config.MaxConnectionLifetime < 0 {
 		config.MaxConnectionLifetime = 0
 	}
 
+	// Max Connection Idle Time
+	if config.MaxConnectionIdleTime < 0 {
+		config.MaxConnectionIdleTime = 0
+	}
+
+	// Connection Liveness Check Timeout
+	if config.ConnectionLivenessCheckTimeout < 0 {
+		config.ConnectionLivenessCheckTimeout = math.MaxInt64
+	}
+
 	// Connection Acquisition Timeout
 	if config.ConnectionAcquisitionTimeout < 0 {
 		config.ConnectionAcquisitionTimeout = -1
@@ -88,6 +88,27 @@ func TestValidateAndNormaliseConfig(rt *testing.T) {
 		}
 	})
 
+	rt.Run("MinIdleConnectionsPerServer less than zero", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MinIdleConnectionsPerServer = -1
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("MinIdleConnectionsPerServer is less than 0 but never returned an error")
+		}
+	})
+
+	rt.Run("MinIdleConnectionsPerServer greater than MaxConnectionPoolSize", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MaxConnectionPoolSize = 2
+		config.MinIdleConnectionsPerServer = 3
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("MinIdleConnectionsPerServer exceeds MaxConnectionPoolSize but never returned an error")
+		}
+	})
+
 	rt.Run("ConnectionAcquisitionTimeout less than zero", func(t *testing.T) {
 		config := defaultConfig()
 
@@ -113,4 +134,56 @@ func TestValidateAndNormaliseConfig(rt *testing.T) {
 			t.Errorf("SocketConnectTimeout should be set to (0 * time.Nanosecond) when negative")
 		}
 	})
+
+	rt.Run("MaxConnectionIdleTime less than zero", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.MaxConnectionIdleTime = -1 * time.Second
+		err := validateAndNormaliseConfig(config)
+		if err != nil {
+			t.Errorf("MaxConnectionIdleTime is negative but returned an error")
+		}
+		if config.MaxConnectionIdleTime != 0 {
+			t.Errorf("MaxConnectionIdleTime should be set to 0 when negative")
+		}
+	})
+
+	rt.Run("ConnectionLivenessCheckTimeout less than zero", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.ConnectionLivenessCheckTimeout = -1 * time.Second
+		err := validateAndNormaliseConfig(config)
+		if err != nil {
+			t.Errorf("ConnectionLivenessCheckTimeout is negative but returned an error")
+		}
+		if config.ConnectionLivenessCheckTimeout != math.MaxInt64 {
+			t.Errorf("ConnectionLivenessCheckTimeout should be set to math.MaxInt64 when negative")
+		}
+	})
+
+	rt.Run("ConnectionLivenessCheckTimeout of zero always checks", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.ConnectionLivenessCheckTimeout = 0
+		err := validateAndNormaliseConfig(config)
+		if err != nil {
+			t.Errorf("ConnectionLivenessCheckTimeout is zero but returned an error")
+		}
+		if config.ConnectionLivenessCheckTimeout != 0 {
+			t.Errorf("ConnectionLivenessCheckTimeout should be kept at 0")
+		}
+	})
+
+	rt.Run("AddressResolver and AddressResolverWithContext both set", func(t *testing.T) {
+		config := defaultConfig()
+
+		config.AddressResolver = func(address ServerAddress) []ServerAddress {
+			return []ServerAddress{address}
+		}
+		config.AddressResolverWithContext = NewDnsResolver(DnsResolverConfig{})
+		err := validateAndNormaliseConfig(config)
+		if err == nil {
+			t.Errorf("AddressResolver and AddressResolverWithContext are both set but never returned an error")
+		}
+	})
 }
@@ -90,6 +90,18 @@ func (e *Neo4jError) IsAuthenticationFailed() bool {
 	return e.Code == "Neo.ClientError.Security.Unauthorized"
 }
 
+func (e *Neo4jError) IsTokenExpired() bool {
+	return e.Code == "Neo.ClientError.Security.TokenExpired"
+}
+
+func (e *Neo4jError) IsDatabaseNotFound() bool {
+	return e.Code == "Neo.ClientError.Database.DatabaseNotFound"
+}
+
+func (e *Neo4jError) IsSecurityError() bool {
+	return e.Category() == "Security"
+}
+
 func (e *Neo4jError) IsRetriableTransient() bool {
 	e.parse()
 	return e.classification == "TransientError"
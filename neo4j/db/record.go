@@ -19,16 +19,86 @@
 
 package db
 
+import "sync"
+
 type Record struct {
 	// Values contains all the values in the record.
+	// Values is nil for records decoded lazily, see NewLazyRecord.
 	Values []interface{}
 	// Keys contains names of the values in the record.
 	// Should not be modified. Same instance is used for all records within the same result.
 	Keys []string
+	lazy *lazyValues
+}
+
+// NewLazyRecord creates a record whose values are decoded on access, by calling decode with
+// the index of the value. Decoded values are cached, decode is called at most once per value.
+// The values of lazy records are accessed with Get and AllValues, their Values field is nil.
+// Values that cannot be decoded are nil, the first decoding error is reported by Err.
+func NewLazyRecord(size int, decode func(i int) (interface{}, error)) *Record {
+	return &Record{lazy: &lazyValues{
+		decode:  decode,
+		values:  make([]interface{}, size),
+		decoded: make([]bool, size),
+	}}
+}
+
+type lazyValues struct {
+	mut     sync.Mutex
+	decode  func(i int) (interface{}, error)
+	values  []interface{}
+	decoded []bool
+	err     error
+}
+
+func (l *lazyValues) get(i int) interface{} {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	if !l.decoded[i] {
+		value, err := l.decode(i)
+		if err != nil && l.err == nil {
+			l.err = err
+		}
+		l.values[i] = value
+		l.decoded[i] = true
+	}
+	return l.values[i]
+}
+
+func (l *lazyValues) getErr() error {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return l.err
+}
+
+func (l *lazyValues) all() []interface{} {
+	for i := range l.values {
+		l.get(i)
+	}
+	return l.values
+}
+
+// AllValues returns all the values in the record.
+// It returns Values for records that are not decoded lazily, and decodes all values otherwise.
+func (r Record) AllValues() []interface{} {
+	if r.lazy != nil {
+		return r.lazy.all()
+	}
+	return r.Values
+}
+
+// Err returns the error that occurred decoding the values of a record decoded lazily, see NewLazyRecord.
+// Only the values accessed so far are decoded. Records that are not decoded lazily have no error.
+func (r Record) Err() error {
+	if r.lazy != nil {
+		return r.lazy.getErr()
+	}
+	return nil
 }
 
 // Get returns the value corresponding to the given key along with a boolean that is true if
 // a value was found and false if there were no key with the given name.
+// The value of a record decoded lazily is nil when it cannot be decoded, see Err.
 //
 // If there are a lot of keys in combination with a lot of records to iterate, consider to retrieve
 // values from Values slice directly or make a key -> index map before iterating. This implementation
@@ -37,6 +107,9 @@ type Record struct {
 func (r Record) Get(key string) (interface{}, bool) {
 	for i, ckey := range r.Keys {
 		if key == ckey {
+			if r.lazy != nil {
+				return r.lazy.get(i), true
+			}
 			return r.Values[i], true
 		}
 	}
@@ -106,6 +106,8 @@ type Notification struct {
 	Position *InputPosition
 	// Severity contains the severity level of this notification.
 	Severity string
+	// Category contains the category of this notification, empty if the server did not provide any.
+	Category string
 }
 
 // InputPosition contains information about a specific position in a statement
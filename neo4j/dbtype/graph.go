@@ -20,6 +20,14 @@
 // Package dbtype contains definitions of supported database types.
 package dbtype
 
+// Entity is implemented by the graph entities holding properties, Node and Relationship.
+type Entity interface {
+	// GetElementId returns the element id of the entity.
+	GetElementId() string
+	// GetProperties returns the properties of the entity.
+	GetProperties() map[string]interface{}
+}
+
 // Node represents a node in the neo4j graph database
 type Node struct {
 	// Deprecated: Id is deprecated and will be removed in 6.0. Use ElementId instead.
@@ -29,6 +37,16 @@ type Node struct {
 	Props     map[string]interface{} // Properties of this Node.
 }
 
+// GetElementId returns the element id of this Node.
+func (n Node) GetElementId() string {
+	return n.ElementId
+}
+
+// GetProperties returns the properties of this Node.
+func (n Node) GetProperties() map[string]interface{} {
+	return n.Props
+}
+
 // Relationship represents a relationship in the neo4j graph database
 type Relationship struct {
 	// Deprecated: Id is deprecated and will be removed in 6.0. Use ElementId instead.
@@ -44,6 +62,16 @@ type Relationship struct {
 	Props        map[string]interface{} // Properties of this Relationship.
 }
 
+// GetElementId returns the element id of this Relationship.
+func (r Relationship) GetElementId() string {
+	return r.ElementId
+}
+
+// GetProperties returns the properties of this Relationship.
+func (r Relationship) GetProperties() map[string]interface{} {
+	return r.Props
+}
+
 // Path represents a directed sequence of relationships between two nodes.
 // This generally represents a traversal or walk through a graph and maintains a direction separate from that of any
 // relationships traversed. It is allowed to be of size 0, meaning there are no relationships in it. In this case,
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package dbtype
+
+// ValueMarshaler is implemented by types that convert themselves to query parameter values.
+// MarshalNeo4j returns the value sent in place of the receiver, it must be of a type supported
+// as query parameter and must not be a ValueMarshaler itself.
+//
+// ValueMarshaler takes precedence over encoding.TextMarshaler, which is only considered for
+// struct and array types the driver cannot otherwise encode.
+type ValueMarshaler interface {
+	MarshalNeo4j() (interface{}, error)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
 	"net/url"
 	"strings"
 
@@ -65,11 +66,46 @@ type DriverWithContext interface {
 	// GetServerInfo attempts to obtain server information from the target Neo4j
 	// deployment
 	GetServerInfo(ctx context.Context) (ServerInfo, error)
+	// ExecuteQueryBookmarkManager returns the bookmark manager instance used by ExecuteQuery by default.
+	//
+	// This is useful when ExecuteQuery is called without custom bookmark managers and the lower-level
+	// SessionWithContext APIs are called as well.
+	// In that case, the recommended approach is as follows:
+	//	results, err := neo4j.ExecuteQuery(ctx, driver, query, params, neo4j.EagerResultTransformer)
+	//	// [...] do something with results and error
+	//	bookmarkManager := driver.ExecuteQueryBookmarkManager()
+	//	// maintain consistency with sessions as well
+	//	session := driver.NewSession(ctx, neo4j.SessionConfig{BookmarkManager: bookmarkManager})
+	//	// [...] run something within the session
+	ExecuteQueryBookmarkManager() BookmarkManager
+	// Metrics returns a snapshot of the driver metrics, such as the connection pool usage per server.
+	// Metrics can be polled periodically to feed a monitoring system.
+	// Calling Metrics on a closed driver returns an error.
+	Metrics(ctx context.Context) (DriverMetrics, error)
+	// WarmUp establishes connections to the readers and writers of the default database ahead of their first use,
+	// so that the first queries do not pay for connecting, for instance right after a deployment or a failover.
+	// At least one connection, or Config.MinIdleConnectionsPerServer connections, is established per server.
+	// When Config.MinIdleConnectionsPerServer is set, these servers are then kept warm in the background.
+	// Connecting to all servers is attempted even when some of them fail, the first error is returned.
+	WarmUp(ctx context.Context) error
+	// RoutingTable returns the routing table the driver currently uses for the database, for instance to report
+	// which servers the driver considers to be readers and writers.
+	// The routing table is read first when the driver has none or when it expired.
+	// An empty database name stands for the home database of the driver user.
+	// Routing tables are only available with neo4j URI schemes.
+	RoutingTable(ctx context.Context, database string) (RoutingTable, error)
+	// RefreshRoutingTable reads the routing table of the database again regardless of its expiry and returns it,
+	// for instance after a planned failover.
+	// An empty database name stands for the home database of the driver user.
+	// Routing tables are only available with neo4j URI schemes.
+	RefreshRoutingTable(ctx context.Context, database string) (RoutingTable, error)
 }
 
 // NewDriverWithContext is the entry point to the neo4j driver to create an instance of a Driver. It is the first function to
 // be called in order to establish a connection to a neo4j database. It requires a Bolt URI and an authentication
 // token as parameters and can also take optional configuration function(s) as variadic parameters.
+// Instead of a static authentication token, an AuthTokenManager can be provided to supply tokens on demand,
+// see BasicAuthTokenManager and BearerAuthTokenManager.
 //
 // In order to connect to a single instance database, you need to pass a URI with scheme 'bolt', 'bolt+s' or 'bolt+ssc'.
 //	driver, err = NewDriverWithContext("bolt://db.server:7687", BasicAuth(username, password))
@@ -82,13 +118,20 @@ type DriverWithContext interface {
 //	driver, err = NewDriverWithContext(uri, BasicAuth(username, password), function (config *Config) {
 // 		config.MaxConnectionPoolSize = 10
 // 	})
-func NewDriverWithContext(target string, auth AuthToken, configurers ...func(*Config)) (DriverWithContext, error) {
+func NewDriverWithContext(target string, auth AuthTokenManager, configurers ...func(*Config)) (DriverWithContext, error) {
+	if auth == nil {
+		return nil, &UsageError{Message: "Authentication token or token manager is required, use NoAuth() for none"}
+	}
 	parsed, err := url.Parse(target)
 	if err != nil {
 		return nil, err
 	}
 
-	d := driverWithContext{target: parsed, mut: racing.NewMutex()}
+	d := driverWithContext{
+		target:                      parsed,
+		mut:                         racing.NewMutex(),
+		executeQueryBookmarkManager: NewBookmarkManager(BookmarkManagerConfig{}),
+	}
 
 	routing := true
 	d.connector.Network = "tcp"
@@ -150,26 +193,58 @@ func NewDriverWithContext(target string, auth AuthToken, configurers ...func(*Co
 		// Default to void logger
 		d.log = &log.Void{}
 	}
+	if d.config.Tracer == nil {
+		d.config.Tracer = tracing.Void{}
+	}
 	d.logId = log.NewId()
 
 	routingContext, err := routingContextFromUrl(routing, parsed)
 	if err != nil {
 		return nil, err
 	}
+	if policy := d.config.RoutingPolicy; policy != "" {
+		if !routing {
+			return nil, &UsageError{
+				Message: fmt.Sprintf("Routing policy is not supported for URL scheme %s", parsed.Scheme),
+			}
+		}
+		if urlPolicy, found := routingContext[router.PolicyKey]; found && urlPolicy != policy {
+			return nil, &UsageError{
+				Message: fmt.Sprintf("Routing policy '%s' conflicts with policy '%s' of the URL", policy, urlPolicy),
+			}
+		}
+		routingContext[router.PolicyKey] = policy
+	}
 
 	// Continue to setup connector
 	d.connector.DialTimeout = d.config.SocketConnectTimeout
 	d.connector.SocketKeepAlive = d.config.SocketKeepalive
 	d.connector.UserAgent = d.config.UserAgent
+	d.connector.BoltAgentProduct = boltAgentProduct
+	d.connector.Codecs = d.config.Codecs
+	d.connector.LazyRecords = d.config.LazyRecordDecoding
 	d.connector.RootCAs = d.config.RootCAs
 	d.connector.TlsConfig = d.config.TlsConfig
 	d.connector.Log = d.log
-	d.connector.Auth = auth.tokens
+	d.connector.NotificationConfig = toNotificationConfig(d.config.NotificationsMinSeverity,
+		d.config.NotificationsDisabledCategories)
+	if token, isStatic := auth.(AuthToken); isStatic {
+		d.connector.Auth = token.tokens
+	}
+	d.auth = auth
 	d.connector.RoutingContext = routingContext
+	if routing && d.config.AddressResolverWithContext != nil {
+		d.connector.ServerNames = connector.NewServerNames()
+	}
 
 	// Let the pool use the same log ID as the driver to simplify log reading.
 	d.pool = pool.New(d.config.MaxConnectionPoolSize, d.config.MaxConnectionLifetime, d.connector.Connect, d.log, d.logId)
+	d.pool.SetMinIdle(d.config.MinIdleConnectionsPerServer, d.config.ConnectionAcquisitionTimeout)
+	d.pool.SetMaxIdleTime(d.config.MaxConnectionIdleTime)
+	d.pool.SetSelectServer(toSelectServer(d.config.LoadBalancingStrategy))
+	d.pool.SetAuthTokenSupplier(d.getAuthToken)
 
+	var knownServers func(context.Context) ([]string, error)
 	if !routing {
 		d.router = &directRouter{address: address}
 	} else {
@@ -186,8 +261,15 @@ func NewDriverWithContext(target string, auth AuthToken, configurers ...func(*Co
 			}
 		}
 		// Let the router use the same log ID as the driver to simplify log reading.
-		d.router = router.New(address, routersResolver, routingContext, d.pool, d.log, d.logId)
+		clusterRouter := router.New(address, routersResolver, routingContext, d.pool, d.log, d.logId)
+		clusterRouter.SetTableListener(toTableListener(d.config.RoutingTableListener))
+		if d.config.AddressResolverWithContext != nil {
+			clusterRouter.SetResolver(toRouterResolver(d.config.AddressResolverWithContext, d.connector.ServerNames))
+		}
+		knownServers = clusterRouter.KnownServers
+		d.router = clusterRouter
 	}
+	d.pool.StartHousekeeping(d.config.ConnectionHousekeepingInterval, knownServers)
 
 	d.log.Infof(log.Driver, d.logId, "Created { target: %s }", address)
 	return &d, nil
@@ -230,12 +312,12 @@ func routingContextFromUrl(useRouting bool, u *url.URL) (map[string]string, erro
 
 type sessionRouter interface {
 	// Readers returns the list of servers that can serve reads on the requested database.
-	Readers(ctx context.Context, bookmarks []string, database string, boltLogger log.BoltLogger) ([]string, error)
+	Readers(ctx context.Context, bookmarks []string, database string, auth *db.ReAuthToken, boltLogger log.BoltLogger) ([]string, error)
 	// Writers returns the list of servers that can serve writes on the requested database.
-	Writers(ctx context.Context, bookmarks []string, database string, boltLogger log.BoltLogger) ([]string, error)
+	Writers(ctx context.Context, bookmarks []string, database string, auth *db.ReAuthToken, boltLogger log.BoltLogger) ([]string, error)
 	// GetNameOfDefaultDatabase returns the name of the default database for the specified user.
 	// The correct database name is needed when requesting readers or writers.
-	GetNameOfDefaultDatabase(ctx context.Context, bookmarks []string, user string, boltLogger log.BoltLogger) (string, error)
+	GetNameOfDefaultDatabase(ctx context.Context, bookmarks []string, user string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (string, error)
 	Invalidate(ctx context.Context, database string) error
 	CleanUp(ctx context.Context) error
 	InvalidateWriter(ctx context.Context, name string, server string) error
@@ -251,6 +333,9 @@ type driverWithContext struct {
 	router    sessionRouter
 	logId     string
 	log       log.Logger
+	auth      AuthTokenManager
+	// bookmark manager shared by all ExecuteQuery calls that do not specify their own
+	executeQueryBookmarkManager BookmarkManager
 }
 
 func (d *driverWithContext) Target() url.URL {
@@ -271,7 +356,16 @@ func (d *driverWithContext) NewSession(ctx context.Context, config SessionConfig
 		return &erroredSessionWithContext{
 			err: &UsageError{Message: "Trying to create session on closed driver"}}
 	}
-	return newSessionWithContext(d.config, config, d.router, d.pool, d.log)
+	sessRouter := d.router
+	if config.RoutingPolicy != "" {
+		clusterRouter, isRouting := d.router.(*router.Router)
+		if !isRouting {
+			return &erroredSessionWithContext{
+				err: &UsageError{Message: fmt.Sprintf("Routing policy is not supported for URL scheme %s", d.target.Scheme)}}
+		}
+		sessRouter = clusterRouter.WithPolicy(config.RoutingPolicy)
+	}
+	return newSessionWithContext(d.config, config, sessRouter, d.pool, d.auth, d.log)
 }
 
 func (d *driverWithContext) VerifyConnectivity(ctx context.Context) error {
@@ -291,6 +385,111 @@ func (d *driverWithContext) GetServerInfo(ctx context.Context) (_ ServerInfo, er
 	return session.getServerInfo(ctx)
 }
 
+func (d *driverWithContext) ExecuteQueryBookmarkManager() BookmarkManager {
+	return d.executeQueryBookmarkManager
+}
+
+func (d *driverWithContext) Metrics(ctx context.Context) (DriverMetrics, error) {
+	if !d.mut.TryLock(ctx) {
+		return DriverMetrics{}, racing.LockTimeoutError("could not acquire lock in time when collecting metrics")
+	}
+	defer d.mut.Unlock()
+	if d.pool == nil {
+		return DriverMetrics{}, &UsageError{Message: "Trying to collect metrics of closed driver"}
+	}
+	return newDriverMetrics(d.pool.Metrics()), nil
+}
+
+func (d *driverWithContext) WarmUp(ctx context.Context) error {
+	if !d.mut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire lock in time when warming up driver")
+	}
+	driverPool, driverRouter := d.pool, d.router
+	d.mut.Unlock()
+	if driverPool == nil {
+		return &UsageError{Message: "Trying to warm up closed driver"}
+	}
+	auth, err := d.getAuthToken(ctx)
+	if err != nil {
+		return err
+	}
+	database, err := driverRouter.GetNameOfDefaultDatabase(ctx, nil, "", auth, nil)
+	if err != nil {
+		return err
+	}
+	readers, err := driverRouter.Readers(ctx, nil, database, auth, nil)
+	if err != nil {
+		return err
+	}
+	writers, err := driverRouter.Writers(ctx, nil, database, auth, nil)
+	if err != nil {
+		return err
+	}
+	servers := make([]string, 0, len(readers)+len(writers))
+	known := make(map[string]bool, len(readers)+len(writers))
+	for _, roleServers := range [][]string{writers, readers} {
+		for _, server := range roleServers {
+			if !known[server] {
+				known[server] = true
+				servers = append(servers, server)
+			}
+		}
+	}
+	d.log.Infof(log.Driver, d.logId, "Warming up connections to %v", servers)
+	return driverPool.WarmUp(ctx, servers, auth)
+}
+
+func (d *driverWithContext) RoutingTable(ctx context.Context, database string) (RoutingTable, error) {
+	return d.routingTable(ctx, database, false)
+}
+
+func (d *driverWithContext) RefreshRoutingTable(ctx context.Context, database string) (RoutingTable, error) {
+	return d.routingTable(ctx, database, true)
+}
+
+func (d *driverWithContext) routingTable(ctx context.Context, database string, refresh bool) (RoutingTable, error) {
+	if !d.mut.TryLock(ctx) {
+		return RoutingTable{}, racing.LockTimeoutError("could not acquire lock in time when getting routing table")
+	}
+	driverPool, driverRouter := d.pool, d.router
+	d.mut.Unlock()
+	if driverPool == nil {
+		return RoutingTable{}, &UsageError{Message: "Trying to get routing table of closed driver"}
+	}
+	clusterRouter, isRouting := driverRouter.(*router.Router)
+	if !isRouting {
+		return RoutingTable{}, &UsageError{Message: fmt.Sprintf("Routing tables are not available for URL scheme %s", d.target.Scheme)}
+	}
+	auth, err := d.getAuthToken(ctx)
+	if err != nil {
+		return RoutingTable{}, err
+	}
+	if database == "" {
+		if database, err = clusterRouter.GetNameOfDefaultDatabase(ctx, nil, "", auth, nil); err != nil {
+			return RoutingTable{}, err
+		}
+	}
+	getTable := clusterRouter.RoutingTable
+	if refresh {
+		getTable = clusterRouter.RefreshRoutingTable
+	}
+	table, expiresAt, err := getTable(ctx, database, auth)
+	if err != nil {
+		return RoutingTable{}, err
+	}
+	return newRoutingTable(table, expiresAt), nil
+}
+
+// Returns the token of the driver, also when it is static, so that idle connections that have been
+// re-authenticated with a session token are switched back to the driver token before being used
+func (d *driverWithContext) getAuthToken(ctx context.Context) (*db.ReAuthToken, error) {
+	token, err := d.auth.GetAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &db.ReAuthToken{Token: token.tokens}, nil
+}
+
 func (d *driverWithContext) Close(ctx context.Context) error {
 	if !d.mut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire lock in time when closing driver")
@@ -306,3 +505,242 @@ func (d *driverWithContext) Close(ctx context.Context) error {
 	d.log.Infof(log.Driver, d.logId, "Closed")
 	return nil
 }
+
+// ExecuteQuery runs the specified query with its parameters and returns the query result, transformed by the specified
+// ResultTransformer function.
+//
+//	result, err := ExecuteQuery[*EagerResult](ctx, driver, query, params, EagerResultTransformer)
+//
+// Passing a nil ResultTransformer function is invalid and will return an error.
+//
+// Likewise, passing a function that returns a nil ResultTransformer is invalid and will return an error.
+//
+// ExecuteQuery runs the query in a single explicit, retryable transaction within a session entirely managed by
+// the driver. Retries occur in the same conditions as when calling SessionWithContext.ExecuteRead and
+// SessionWithContext.ExecuteWrite.
+//
+// Unless configured otherwise, successive ExecuteQuery calls are causally chained, via the bookmark manager returned
+// by DriverWithContext.ExecuteQueryBookmarkManager.
+//
+// Because it is an explicit transaction from the server point of view, Cypher queries using
+// "CALL {} IN TRANSACTIONS" or the older "USING PERIODIC COMMIT" construct will not work (call
+// SessionWithContext.Run for these).
+//
+// Specific settings can be configured via configuration callbacks. Built-in callbacks are provided such as:
+//
+//	neo4j.ExecuteQueryWithDatabase
+//	neo4j.ExecuteQueryWithWritersRouting
+//	...
+//
+// see ExecuteQueryConfiguration for all possibilities.
+//
+// These built-in callbacks can be used and combined as follows:
+//
+//	ExecuteQuery[T](ctx, driver, query, params, transformerFunc,
+//		neo4j.ExecuteQueryWithDatabase("my-db"),
+//		neo4j.ExecuteQueryWithReadersRouting())
+//
+// For complete control over the configuration, you can also define your own callback:
+//
+//	ExecuteQuery[T](ctx, driver, query, params, transformerFunc, func(config *neo4j.ExecuteQueryConfiguration) {
+//		config.Database = "my-db"
+//		config.Routing = neo4j.Read
+//		config.ImpersonatedUser = "selda_bağcan"
+//	})
+func ExecuteQuery[T any](
+	ctx context.Context,
+	driver DriverWithContext,
+	query string,
+	parameters map[string]interface{},
+	newResultTransformer func() ResultTransformer[T],
+	settings ...ExecuteQueryConfigurationOption) (res T, err error) {
+
+	if driver == nil {
+		return *new(T), &UsageError{Message: "nil is not a valid DriverWithContext argument."}
+	}
+	if newResultTransformer == nil {
+		return *new(T), &UsageError{Message: "nil is not a valid ResultTransformer function argument. " +
+			"Consider passing EagerResultTransformer or a function that returns an instance of your own " +
+			"ResultTransformer implementation"}
+	}
+	configuration := &ExecuteQueryConfiguration{BookmarkManager: driver.ExecuteQueryBookmarkManager()}
+	for _, setter := range settings {
+		setter(configuration)
+	}
+	session := driver.NewSession(ctx, configuration.toSessionConfig())
+	defer func() {
+		err = deferredClose(ctx, session, err)
+	}()
+	txFunction, err := configuration.selectTxFunctionApi(session)
+	if err != nil {
+		return *new(T), err
+	}
+	result, err := txFunction(ctx, executeQueryCallback(ctx, newResultTransformer, query, parameters))
+	if err != nil {
+		return *new(T), err
+	}
+	return result.(T), nil
+}
+
+func executeQueryCallback[T any](
+	ctx context.Context,
+	newResultTransformer func() ResultTransformer[T],
+	query string,
+	parameters map[string]interface{}) ManagedTransactionWork {
+
+	return func(tx ManagedTransaction) (interface{}, error) {
+		resultTransformer := newResultTransformer()
+		if resultTransformer == nil {
+			return nil, &UsageError{Message: "expected the result transformer function to return a valid " +
+				"ResultTransformer instance, but got nil"}
+		}
+		results, err := tx.Run(ctx, query, parameters)
+		if err != nil {
+			return nil, err
+		}
+		for results.Next(ctx) {
+			if err := resultTransformer.Accept(results.Record()); err != nil {
+				return nil, err
+			}
+		}
+		if err := results.Err(); err != nil {
+			return nil, err
+		}
+		keys, err := results.Keys()
+		if err != nil {
+			return nil, err
+		}
+		summary, err := results.Consume(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resultTransformer.Complete(keys, summary)
+	}
+}
+
+// ExecuteQueryConfigurationOption is a callback that configures the execution of DriverWithContext.ExecuteQuery
+type ExecuteQueryConfigurationOption func(*ExecuteQueryConfiguration)
+
+// ExecuteQueryWithReadersRouting configures DriverWithContext.ExecuteQuery to route to reader members of the cluster
+func ExecuteQueryWithReadersRouting() ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.Routing = Read
+	}
+}
+
+// ExecuteQueryWithWritersRouting configures DriverWithContext.ExecuteQuery to route to writer members of the cluster
+func ExecuteQueryWithWritersRouting() ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.Routing = Write
+	}
+}
+
+// ExecuteQueryWithImpersonatedUser configures DriverWithContext.ExecuteQuery to impersonate the specified user
+func ExecuteQueryWithImpersonatedUser(user string) ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.ImpersonatedUser = user
+	}
+}
+
+// ExecuteQueryWithDatabase configures DriverWithContext.ExecuteQuery to target the specified database
+func ExecuteQueryWithDatabase(db string) ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.Database = db
+	}
+}
+
+// ExecuteQueryWithBookmarkManager configures DriverWithContext.ExecuteQuery to rely on the specified BookmarkManager
+func ExecuteQueryWithBookmarkManager(bookmarkManager BookmarkManager) ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.BookmarkManager = bookmarkManager
+	}
+}
+
+// ExecuteQueryWithoutBookmarkManager configures DriverWithContext.ExecuteQuery to not rely on any BookmarkManager
+func ExecuteQueryWithoutBookmarkManager() ExecuteQueryConfigurationOption {
+	return func(configuration *ExecuteQueryConfiguration) {
+		configuration.BookmarkManager = nil
+	}
+}
+
+// ExecuteQueryConfiguration holds all the possible configuration settings for DriverWithContext.ExecuteQuery
+type ExecuteQueryConfiguration struct {
+	Routing          RoutingControl
+	ImpersonatedUser string
+	Database         string
+	BookmarkManager  BookmarkManager
+}
+
+// RoutingControl specifies how the query executed by DriverWithContext.ExecuteQuery is to be routed
+type RoutingControl int
+
+const (
+	// Write routes the query to execute to a writer member of the cluster
+	Write RoutingControl = iota
+	// Read routes the query to execute to a reader member of the cluster
+	Read
+)
+
+func (c *ExecuteQueryConfiguration) toSessionConfig() SessionConfig {
+	return SessionConfig{
+		ImpersonatedUser: c.ImpersonatedUser,
+		DatabaseName:     c.Database,
+		BookmarkManager:  c.BookmarkManager,
+		executeQuery:     true,
+	}
+}
+
+type transactionFunction func(context.Context, ManagedTransactionWork, ...func(*TransactionConfig)) (interface{}, error)
+
+func (c *ExecuteQueryConfiguration) selectTxFunctionApi(session SessionWithContext) (transactionFunction, error) {
+	switch c.Routing {
+	case Read:
+		return session.ExecuteRead, nil
+	case Write:
+		return session.ExecuteWrite, nil
+	}
+	return nil, fmt.Errorf("unsupported routing control, expected %d (Write) or %d (Read) "+
+		"but got: %d", Write, Read, c.Routing)
+}
+
+// ResultTransformer is a record accumulator that produces an instance of T when the processing of records is over.
+type ResultTransformer[T any] interface {
+	// Accept is called whenever a new record is fetched from the server
+	// Implementers are free to accumulate or discard the specified record
+	Accept(*Record) error
+
+	// Complete is called when the record fetching is over and no error occurred.
+	// In particular, it is important to note that Accept may be called several times before an error occurs.
+	// In that case, Complete will not be called.
+	Complete(keys []string, summary ResultSummary) (T, error)
+}
+
+// EagerResultTransformer returns a ResultTransformer that collects all the records, keys and the summary of the
+// query result into an EagerResult instance
+func EagerResultTransformer() ResultTransformer[*EagerResult] {
+	return &eagerResultTransformer{}
+}
+
+type eagerResultTransformer struct {
+	records []*Record
+}
+
+func (e *eagerResultTransformer) Accept(record *Record) error {
+	e.records = append(e.records, record)
+	return nil
+}
+
+func (e *eagerResultTransformer) Complete(keys []string, summary ResultSummary) (*EagerResult, error) {
+	return &EagerResult{
+		Keys:    keys,
+		Records: e.records,
+		Summary: summary,
+	}, nil
+}
+
+// EagerResult holds the result and result metadata of the query executed via DriverWithContext.ExecuteQuery
+type EagerResult struct {
+	Keys    []string
+	Records []*Record
+	Summary ResultSummary
+}
@@ -0,0 +1,531 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+)
+
+// driverDelegate creates sessions backed by fake routers and pools
+type driverDelegate struct {
+	router          *RouterFake
+	pool            *PoolFake
+	sessionConfig   SessionConfig
+	bookmarkManager BookmarkManager
+}
+
+func (d *driverDelegate) Target() url.URL {
+	return url.URL{}
+}
+
+func (d *driverDelegate) NewSession(_ context.Context, config SessionConfig) SessionWithContext {
+	d.sessionConfig = config
+	conf := Config{MaxTransactionRetryTime: 3 * time.Millisecond}
+	sess := newSessionWithContext(&conf, config, d.router, d.pool, nil, &log.Void{})
+	sess.throttleTime = time.Millisecond * 1
+	return sess
+}
+
+func (d *driverDelegate) VerifyConnectivity(context.Context) error {
+	return nil
+}
+
+func (d *driverDelegate) Close(context.Context) error {
+	return nil
+}
+
+func (d *driverDelegate) IsEncrypted() bool {
+	return false
+}
+
+func (d *driverDelegate) GetServerInfo(context.Context) (ServerInfo, error) {
+	return nil, nil
+}
+
+func (d *driverDelegate) ExecuteQueryBookmarkManager() BookmarkManager {
+	return d.bookmarkManager
+}
+
+func (d *driverDelegate) Metrics(context.Context) (DriverMetrics, error) {
+	return DriverMetrics{}, nil
+}
+
+func (d *driverDelegate) WarmUp(context.Context) error {
+	return nil
+}
+
+func (d *driverDelegate) RoutingTable(context.Context, string) (RoutingTable, error) {
+	return RoutingTable{}, nil
+}
+
+func (d *driverDelegate) RefreshRoutingTable(context.Context, string) (RoutingTable, error) {
+	return RoutingTable{}, nil
+}
+
+func TestNewDriverWithContext(outer *testing.T) {
+	outer.Run("fails without authentication token manager", func(t *testing.T) {
+		_, err := NewDriverWithContext("neo4j://localhost:7687", nil)
+
+		AssertTrue(t, IsUsageError(err))
+		AssertErrorMessageContains(t, err, "NoAuth")
+	})
+}
+
+func TestExecuteQuery(outer *testing.T) {
+	ctx := context.Background()
+	query := "RETURN 42 AS n"
+	params := map[string]interface{}{"a": 1}
+
+	createDriver := func(conn *ConnFake) *driverDelegate {
+		return &driverDelegate{
+			router:          &RouterFake{},
+			pool:            &PoolFake{BorrowConn: conn},
+			bookmarkManager: NewBookmarkManager(BookmarkManagerConfig{}),
+		}
+	}
+
+	outer.Run("collects records and summary eagerly", func(t *testing.T) {
+		record := &db.Record{Keys: []string{"n"}, Values: []interface{}{42}}
+		summary := &db.Summary{Bookmark: "b1"}
+		conn := &ConnFake{
+			Alive:      true,
+			Nexts:      []Next{{Record: record}, {Summary: summary}},
+			ConsumeSum: summary,
+		}
+		driver := createDriver(conn)
+
+		result, err := ExecuteQuery(ctx, driver, query, params, EagerResultTransformer)
+
+		AssertNoError(t, err)
+		AssertLen(t, result.Records, 1)
+		AssertDeepEquals(t, result.Records[0], record)
+		AssertNotNil(t, result.Summary)
+		AssertStringEqual(t, result.Summary.Query().Text(), query)
+	})
+
+	outer.Run("routes to writers by default", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+		driver := createDriver(conn)
+
+		_, err := ExecuteQuery(ctx, driver, query, params, EagerResultTransformer)
+
+		AssertNoError(t, err)
+		AssertLen(t, conn.RecordedTxs, 1)
+		AssertIntEqual(t, int(conn.RecordedTxs[0].Mode), int(idb.WriteMode))
+	})
+
+	outer.Run("routes to readers when configured", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+		driver := createDriver(conn)
+
+		_, err := ExecuteQuery(ctx, driver, query, params, EagerResultTransformer, ExecuteQueryWithReadersRouting())
+
+		AssertNoError(t, err)
+		AssertLen(t, conn.RecordedTxs, 1)
+		AssertIntEqual(t, int(conn.RecordedTxs[0].Mode), int(idb.ReadMode))
+	})
+
+	outer.Run("honours database and impersonated user", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}}
+		driver := createDriver(conn)
+
+		_, err := ExecuteQuery(ctx, driver, query, params, EagerResultTransformer,
+			ExecuteQueryWithDatabase("mydb"),
+			ExecuteQueryWithImpersonatedUser("jane"))
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, driver.sessionConfig.DatabaseName, "mydb")
+		AssertStringEqual(t, driver.sessionConfig.ImpersonatedUser, "jane")
+		AssertStringEqual(t, conn.DatabaseName, "mydb")
+	})
+
+	outer.Run("uses a fresh transformer for every retry", func(t *testing.T) {
+		transientErr := &db.Neo4jError{Code: "Neo.TransientError.General.MemoryPoolOutOfMemoryError"}
+		conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}, TxCommitErr: transientErr}
+		driver := createDriver(conn)
+		transformerCalls := 0
+		conn.TxCommitHook = func() {
+			if transformerCalls > 1 {
+				conn.TxCommitErr = nil
+			}
+		}
+
+		_, err := ExecuteQuery(ctx, driver, query, params, func() ResultTransformer[*EagerResult] {
+			transformerCalls++
+			return EagerResultTransformer()
+		})
+
+		AssertNoError(t, err)
+		AssertIntEqual(t, transformerCalls, 2)
+	})
+
+	outer.Run("returns transformer errors", func(t *testing.T) {
+		record := &db.Record{Keys: []string{"n"}, Values: []interface{}{42}}
+		conn := &ConnFake{Alive: true, Nexts: []Next{{Record: record}, {Summary: &db.Summary{}}}}
+		driver := createDriver(conn)
+		transformerErr := errors.New("oopsie")
+
+		_, err := ExecuteQuery(ctx, driver, query, params, func() ResultTransformer[int] {
+			return &failingTransformer{err: transformerErr}
+		})
+
+		AssertDeepEquals(t, err, transformerErr)
+	})
+
+	outer.Run("causally chains queries with the default bookmark manager", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}, Bookm: "b1"}
+		driver := createDriver(conn)
+
+		_, err := ExecuteQuery(ctx, driver, query, params, EagerResultTransformer)
+		AssertNoError(t, err)
+		conn.Bookm = "b2"
+		_, err = ExecuteQuery(ctx, driver, query, params, EagerResultTransformer)
+		AssertNoError(t, err)
+
+		AssertLen(t, conn.RecordedTxs, 2)
+		AssertLen(t, conn.RecordedTxs[0].Bookmarks, 0)
+		AssertDeepEquals(t, conn.RecordedTxs[1].Bookmarks, []string{"b1"})
+		bookmarks, err := driver.bookmarkManager.GetBookmarks(ctx, "")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, bookmarks, Bookmarks{"b2"})
+	})
+
+	outer.Run("does not chain queries without bookmark manager", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, ConsumeSum: &db.Summary{}, Bookm: "b1"}
+		driver := createDriver(conn)
+
+		_, err := ExecuteQuery(ctx, driver, query, params, EagerResultTransformer, ExecuteQueryWithoutBookmarkManager())
+		AssertNoError(t, err)
+		_, err = ExecuteQuery(ctx, driver, query, params, EagerResultTransformer, ExecuteQueryWithoutBookmarkManager())
+		AssertNoError(t, err)
+
+		AssertLen(t, conn.RecordedTxs, 2)
+		AssertLen(t, conn.RecordedTxs[1].Bookmarks, 0)
+	})
+
+	outer.Run("rejects nil driver", func(t *testing.T) {
+		_, err := ExecuteQuery(ctx, nil, query, params, EagerResultTransformer)
+
+		AssertTrue(t, IsUsageError(err))
+	})
+
+	outer.Run("rejects nil result transformer function", func(t *testing.T) {
+		driver := createDriver(&ConnFake{Alive: true})
+
+		_, err := ExecuteQuery[*EagerResult](ctx, driver, query, params, nil)
+
+		AssertTrue(t, IsUsageError(err))
+	})
+
+	outer.Run("rejects nil result transformer", func(t *testing.T) {
+		driver := createDriver(&ConnFake{Alive: true, ConsumeSum: &db.Summary{}})
+
+		_, err := ExecuteQuery(ctx, driver, query, params, func() ResultTransformer[*EagerResult] {
+			return nil
+		})
+
+		AssertTrue(t, IsUsageError(err))
+	})
+}
+
+type failingTransformer struct {
+	err error
+}
+
+func (f *failingTransformer) Accept(*Record) error {
+	return f.err
+}
+
+func (f *failingTransformer) Complete([]string, ResultSummary) (int, error) {
+	return 0, nil
+}
+
+func TestDriverMetrics(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("reports no connection pools before connecting", func(t *testing.T) {
+		driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+		AssertNoError(t, err)
+		defer driver.Close(ctx)
+
+		metrics, err := driver.Metrics(ctx)
+
+		AssertNoError(t, err)
+		AssertLen(t, metrics.ConnectionPools, 0)
+	})
+
+	outer.Run("fails on closed driver", func(t *testing.T) {
+		driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+		AssertNoError(t, err)
+		AssertNoError(t, driver.Close(ctx))
+
+		_, err = driver.Metrics(ctx)
+
+		AssertTrue(t, IsUsageError(err))
+	})
+
+	outer.Run("converts pool metrics", func(t *testing.T) {
+		metrics := newDriverMetrics(map[string]pool.ServerMetrics{
+			"localhost:7687": {Idle: 1, InUse: 2, Creating: 3, Created: 4, Closed: 5, FailedToCreate: 6, Acquired: 7,
+				AcquisitionTimeouts: 8, AcquisitionTime: 9 * time.Second, FailedLivenessChecks: 10},
+		})
+
+		AssertDeepEquals(t, metrics, DriverMetrics{ConnectionPools: map[string]ConnectionPoolMetrics{
+			"localhost:7687": {Idle: 1, InUse: 2, Creating: 3, Created: 4, Closed: 5, FailedToCreate: 6, Acquired: 7,
+				AcquisitionTimeouts: 8, TotalAcquisitionTime: 9 * time.Second, FailedLivenessChecks: 10},
+		}})
+	})
+}
+
+func TestDriverWarmUp(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("establishes connections to the default database servers", func(t *testing.T) {
+		var connectedTo []string
+		connect := func(_ context.Context, address string, _ *idb.ReAuthToken, _ log.BoltLogger) (idb.Connection, error) {
+			connectedTo = append(connectedTo, address)
+			return &ConnFake{Name: address, Alive: true, Birth: time.Now()}, nil
+		}
+		driver := &driverWithContext{
+			mut:    racing.NewMutex(),
+			pool:   pool.New(10, time.Hour, connect, &log.Void{}, "pool id"),
+			router: &directRouter{address: "localhost:7687"},
+			auth:   NoAuth(),
+			log:    &log.Void{},
+		}
+		defer driver.Close(ctx)
+
+		err := driver.WarmUp(ctx)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, connectedTo, []string{"localhost:7687"})
+		metrics, err := driver.Metrics(ctx)
+		AssertNoError(t, err)
+		AssertDeepEquals(t, metrics.ConnectionPools["localhost:7687"].Idle, int64(1))
+	})
+
+	outer.Run("establishes connections with the static driver token", func(t *testing.T) {
+		var auths []*idb.ReAuthToken
+		connect := func(_ context.Context, address string, auth *idb.ReAuthToken, _ log.BoltLogger) (idb.Connection, error) {
+			auths = append(auths, auth)
+			return &ConnFake{Name: address, Alive: true, Birth: time.Now()}, nil
+		}
+		token := BasicAuth("neo4j", "pass", "")
+		driver := &driverWithContext{
+			mut:    racing.NewMutex(),
+			pool:   pool.New(10, time.Hour, connect, &log.Void{}, "pool id"),
+			router: &directRouter{address: "localhost:7687"},
+			auth:   token,
+			log:    &log.Void{},
+		}
+		defer driver.Close(ctx)
+
+		err := driver.WarmUp(ctx)
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, auths, []*idb.ReAuthToken{{Token: token.tokens}})
+	})
+
+	outer.Run("fails on closed driver", func(t *testing.T) {
+		driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+		AssertNoError(t, err)
+		AssertNoError(t, driver.Close(ctx))
+
+		err = driver.WarmUp(ctx)
+
+		AssertTrue(t, IsUsageError(err))
+	})
+}
+
+func TestDriverRoutingTable(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("returns and refreshes routing tables", func(t *testing.T) {
+		conn := &ConnFake{Alive: true, Birth: time.Now(), Table: &idb.RoutingTable{
+			TimeToLive: 300,
+			Routers:    []string{"router"},
+			Readers:    []string{"reader1", "reader2"},
+			Writers:    []string{"writer1"},
+		}}
+		connect := func(_ context.Context, address string, _ *idb.ReAuthToken, _ log.BoltLogger) (idb.Connection, error) {
+			conn.Name = address
+			return conn, nil
+		}
+		driverPool := pool.New(10, time.Hour, connect, &log.Void{}, "pool id")
+		driver := &driverWithContext{
+			target: &url.URL{Scheme: "neo4j"},
+			mut:    racing.NewMutex(),
+			pool:   driverPool,
+			router: router.New("router", nil, nil, driverPool, &log.Void{}, "router id"),
+			auth:   NoAuth(),
+			log:    &log.Void{},
+		}
+		defer driver.Close(ctx)
+		before := time.Now()
+
+		table, err := driver.RoutingTable(ctx, "db")
+		AssertNoError(t, err)
+		AssertStringEqual(t, table.DatabaseName, "db")
+		AssertDeepEquals(t, table.Routers, []string{"router"})
+		AssertDeepEquals(t, table.Readers, []string{"reader1", "reader2"})
+		AssertDeepEquals(t, table.Writers, []string{"writer1"})
+		AssertDeepEquals(t, table.TimeToLive, 5*time.Minute)
+		AssertTrue(t, !table.ExpiresAt.Before(before.Add(5*time.Minute).Truncate(time.Second)))
+
+		conn.Table = &idb.RoutingTable{TimeToLive: 300, Readers: []string{"reader1"}, Writers: []string{"writer2"}}
+		table, err = driver.RoutingTable(ctx, "db")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, table.Writers, []string{"writer1"})
+		table, err = driver.RefreshRoutingTable(ctx, "db")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, table.Writers, []string{"writer2"})
+	})
+
+	outer.Run("fails on direct URL schemes", func(t *testing.T) {
+		driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+		AssertNoError(t, err)
+		defer driver.Close(ctx)
+
+		_, err = driver.RoutingTable(ctx, "db")
+		AssertTrue(t, IsUsageError(err))
+		_, err = driver.RefreshRoutingTable(ctx, "db")
+		AssertTrue(t, IsUsageError(err))
+	})
+
+	outer.Run("fails on closed driver", func(t *testing.T) {
+		driver, err := NewDriverWithContext("neo4j://localhost:7687", NoAuth())
+		AssertNoError(t, err)
+		AssertNoError(t, driver.Close(ctx))
+
+		_, err = driver.RoutingTable(ctx, "db")
+
+		AssertTrue(t, IsUsageError(err))
+	})
+}
+
+func TestRoutingTableListener(t *testing.T) {
+	var event RoutingTableEvent
+	listener := toTableListener(func(e RoutingTableEvent) {
+		event = e
+	})
+	expiresAt := time.Now()
+
+	listener(router.TableChange{
+		Reason:   router.TableUpdated,
+		Database: "db",
+		Policy:   "eu",
+		Before: &router.TableSnapshot{
+			Table:     &idb.RoutingTable{TimeToLive: 10, DatabaseName: "db", Readers: []string{"reader"}, Writers: []string{"writer1"}},
+			ExpiresAt: expiresAt,
+		},
+		After: &router.TableSnapshot{
+			Table:     &idb.RoutingTable{TimeToLive: 10, DatabaseName: "db", Readers: []string{"reader"}, Writers: []string{"writer2"}},
+			ExpiresAt: expiresAt.Add(10 * time.Second),
+		},
+		Writers: router.ServersDiff{Added: []string{"writer2"}, Removed: []string{"writer1"}},
+	})
+
+	AssertDeepEquals(t, event, RoutingTableEvent{
+		Reason:        RoutingTableUpdated,
+		DatabaseName:  "db",
+		RoutingPolicy: "eu",
+		Before: &RoutingTable{DatabaseName: "db", Readers: []string{"reader"}, Writers: []string{"writer1"},
+			TimeToLive: 10 * time.Second, ExpiresAt: expiresAt},
+		After: &RoutingTable{DatabaseName: "db", Readers: []string{"reader"}, Writers: []string{"writer2"},
+			TimeToLive: 10 * time.Second, ExpiresAt: expiresAt.Add(10 * time.Second)},
+		AddedWriters:   []string{"writer2"},
+		RemovedWriters: []string{"writer1"},
+	})
+	AssertNil(t, toTableListener(nil))
+}
+
+func TestDriverRoutingPolicy(outer *testing.T) {
+	ctx := context.Background()
+	withPolicy := func(policy string) func(*Config) {
+		return func(config *Config) {
+			config.RoutingPolicy = policy
+		}
+	}
+
+	outer.Run("adds the policy to the routing context", func(t *testing.T) {
+		driver, err := NewDriverWithContext("neo4j://localhost:7687?x=y", NoAuth(), withPolicy("eu"))
+		AssertNoError(t, err)
+		defer driver.Close(ctx)
+
+		clusterRouter := driver.(*driverWithContext).router.(*router.Router)
+		AssertDeepEquals(t, clusterRouter.Context(), map[string]string{"x": "y", "policy": "eu", "address": "localhost:7687"})
+		AssertDeepEquals(t, driver.(*driverWithContext).connector.RoutingContext, clusterRouter.Context())
+	})
+
+	outer.Run("accepts the same policy in the URL", func(t *testing.T) {
+		driver, err := NewDriverWithContext("neo4j://localhost:7687?policy=eu", NoAuth(), withPolicy("eu"))
+
+		AssertNoError(t, err)
+		AssertNoError(t, driver.Close(ctx))
+	})
+
+	outer.Run("fails on conflicting policy in the URL", func(t *testing.T) {
+		_, err := NewDriverWithContext("neo4j://localhost:7687?policy=us", NoAuth(), withPolicy("eu"))
+
+		AssertTrue(t, IsUsageError(err))
+		AssertErrorMessageContains(t, err, "conflicts")
+	})
+
+	outer.Run("fails on direct URL schemes", func(t *testing.T) {
+		_, err := NewDriverWithContext("bolt://localhost:7687", NoAuth(), withPolicy("eu"))
+
+		AssertTrue(t, IsUsageError(err))
+	})
+
+	outer.Run("overrides the policy per session", func(t *testing.T) {
+		driver, err := NewDriverWithContext("neo4j://localhost:7687", NoAuth(), withPolicy("eu"))
+		AssertNoError(t, err)
+		defer driver.Close(ctx)
+
+		session := driver.NewSession(ctx, SessionConfig{RoutingPolicy: "us"})
+		defer session.Close(ctx)
+
+		sessionRouter := session.(*sessionWithContext).router.(*router.Router)
+		AssertStringEqual(t, sessionRouter.Context()["policy"], "us")
+	})
+
+	outer.Run("fails on session policy with direct URL schemes", func(t *testing.T) {
+		driver, err := NewDriverWithContext("bolt://localhost:7687", NoAuth())
+		AssertNoError(t, err)
+		defer driver.Close(ctx)
+
+		session := driver.NewSession(ctx, SessionConfig{RoutingPolicy: "us"})
+
+		_, err = session.Run(ctx, "RETURN 1", nil)
+		AssertTrue(t, IsUsageError(err))
+	})
+}
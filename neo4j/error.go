@@ -134,12 +134,21 @@ func IsTransactionExecutionLimit(err error) bool {
 type TokenExpiredError struct {
 	Code    string
 	Message string
+	cause   *db.Neo4jError
 }
 
 func (e *TokenExpiredError) Error() string {
 	return fmt.Sprintf("TokenExpiredError: %s (%s)", e.Code, e.Message)
 }
 
+// Unwrap returns the server error the TokenExpiredError originates from, if any
+func (e *TokenExpiredError) Unwrap() error {
+	if e.cause == nil {
+		return nil
+	}
+	return e.cause
+}
+
 func wrapError(err error) error {
 	if err == nil {
 		return nil
@@ -165,8 +174,8 @@ func wrapError(err error) error {
 	case *bolt.ConnectionWriteTimeout:
 		return &ConnectivityError{inner: err}
 	case *db.Neo4jError:
-		if e.Code == "Neo.ClientError.Security.TokenExpired" {
-			return &TokenExpiredError{Code: e.Code, Message: e.Msg}
+		if e.IsTokenExpired() {
+			return &TokenExpiredError{Code: e.Code, Message: e.Msg, cause: e}
 		}
 	}
 	return err
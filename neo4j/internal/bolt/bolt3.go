@@ -78,16 +78,18 @@ type bolt3 struct {
 	connId        string
 	logId         string
 	serverVersion string
-	tfirst        int64  // Time that server started streaming
-	bookmark      string // Last bookmark
+	tfirst        int64         // Time that server started streaming
+	runLatency    time.Duration // Time between sending the last RUN and receiving its SUCCESS, until taken
+	bookmark      string        // Last bookmark
 	birthDate     time.Time
 	log           log.Logger
 	err           error // Last fatal error
 	minor         int
 	idleDate      time.Time
+	auth          map[string]interface{} // Authentication token sent in HELLO
 }
 
-func NewBolt3(serverName string, conn net.Conn, logger log.Logger, boltLog log.BoltLogger) *bolt3 {
+func NewBolt3(serverName string, conn net.Conn, valueConfig ValueConfig, logger log.Logger, boltLog log.BoltLogger) *bolt3 {
 	now := time.Now()
 	b := &bolt3{
 		state:      bolt3_unauthorized,
@@ -96,8 +98,10 @@ func NewBolt3(serverName string, conn net.Conn, logger log.Logger, boltLog log.B
 		in: &incoming{
 			buf: make([]byte, 4096),
 			hyd: hydrator{
-				boltLogger: boltLog,
-				boltMajor:  3,
+				boltLogger:  boltLog,
+				boltMajor:   3,
+				codecs:      valueConfig.Codecs,
+				lazyRecords: valueConfig.LazyRecords,
 			},
 			connReadTimeout: -1,
 			logger:          logger,
@@ -117,6 +121,7 @@ func NewBolt3(serverName string, conn net.Conn, logger log.Logger, boltLog log.B
 			b.state = bolt3_dead
 		},
 		boltLogger: boltLog,
+		codecs:     valueConfig.Codecs,
 	}
 	return b
 }
@@ -172,10 +177,13 @@ func (b *bolt3) receiveSuccess(ctx context.Context) *success {
 	}
 }
 
-func (b *bolt3) Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, _ map[string]string) error {
+func (b *bolt3) Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, _ map[string]string, notificationConfig idb.NotificationConfig) error {
 	if err := b.assertState(bolt3_unauthorized); err != nil {
 		return err
 	}
+	if err := checkNotificationFiltering(notificationConfig, b.serverName); err != nil {
+		return err
+	}
 
 	hello := map[string]interface{}{
 		"user_agent": userAgent,
@@ -207,6 +215,7 @@ func (b *bolt3) Connect(ctx context.Context, minor int, auth map[string]interfac
 	b.in.hyd.logId = connectionLogId
 	b.out.logId = connectionLogId
 	b.serverVersion = succ.server
+	b.auth = auth
 
 	// Transition into ready state
 	b.state = bolt3_ready
@@ -230,6 +239,9 @@ func (b *bolt3) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.
 	if err := b.checkImpersonation(txConfig.ImpersonatedUser); err != nil {
 		return 0, err
 	}
+	if err := checkNotificationFiltering(txConfig.NotificationConfig, b.serverName); err != nil {
+		return 0, err
+	}
 
 	tx := &internalTx3{
 		mode:      txConfig.Mode,
@@ -419,6 +431,7 @@ func (b *bolt3) run(ctx context.Context, cypher string, params map[string]interf
 
 	// Append pull all message and send it along with other pending messages
 	b.out.appendPullAll()
+	runStart := time.Now()
 	if b.out.send(ctx, b.conn); b.err != nil {
 		return nil, b.err
 	}
@@ -428,6 +441,7 @@ func (b *bolt3) run(ctx context.Context, cypher string, params map[string]interf
 	if b.err != nil {
 		return nil, b.err
 	}
+	b.runLatency = time.Since(runStart)
 	b.tfirst = succ.tfirst
 	// Change state to streaming
 	if b.state == bolt3_ready {
@@ -448,6 +462,9 @@ func (b *bolt3) Run(ctx context.Context, runCommand idb.Command,
 	if err := b.checkImpersonation(txConfig.ImpersonatedUser); err != nil {
 		return nil, err
 	}
+	if err := checkNotificationFiltering(txConfig.NotificationConfig, b.serverName); err != nil {
+		return nil, err
+	}
 
 	tx := internalTx3{
 		mode:      txConfig.Mode,
@@ -661,6 +678,12 @@ func (b *bolt3) Reset(ctx context.Context) {
 	b.ForceReset(ctx)
 }
 
+func (b *bolt3) TakeRunLatency() time.Duration {
+	latency := b.runLatency
+	b.runLatency = 0
+	return latency
+}
+
 func (b *bolt3) ForceReset(ctx context.Context) {
 	if b.state == bolt3_dead {
 		return
@@ -765,6 +788,18 @@ func (b *bolt3) SetBoltLogger(boltLogger log.BoltLogger) {
 	b.out.boltLogger = boltLogger
 }
 
+func (b *bolt3) IsAuthenticatedWith(auth map[string]interface{}) bool {
+	return authTokensEqual(b.auth, auth)
+}
+
+// ReAuth is only supported from Bolt 5.1 onwards, older versions can only keep their initial token
+func (b *bolt3) ReAuth(_ context.Context, auth *idb.ReAuthToken) error {
+	if b.IsAuthenticatedWith(auth.Token) {
+		return nil
+	}
+	return reAuthNotSupportedError(b.serverName)
+}
+
 func (b *bolt3) Version() db.ProtocolVersion {
 	return db.ProtocolVersion{
 		Major: 3,
@@ -89,8 +89,9 @@ type bolt4 struct {
 	connId        string
 	logId         string
 	serverVersion string
-	tfirst        int64  // Time that server started streaming
-	bookmark      string // Last bookmark
+	tfirst        int64         // Time that server started streaming
+	runLatency    time.Duration // Time between sending the last RUN and receiving its SUCCESS, until taken
+	bookmark      string        // Last bookmark
 	birthDate     time.Time
 	log           log.Logger
 	databaseName  string
@@ -98,9 +99,10 @@ type bolt4 struct {
 	minor         int
 	lastQid       int64 // Last seen qid
 	idleDate      time.Time
+	auth          map[string]interface{} // Authentication token sent in HELLO
 }
 
-func NewBolt4(serverName string, conn net.Conn, logger log.Logger, boltLog log.BoltLogger) *bolt4 {
+func NewBolt4(serverName string, conn net.Conn, valueConfig ValueConfig, logger log.Logger, boltLog log.BoltLogger) *bolt4 {
 	now := time.Now()
 	b := &bolt4{
 		state:      bolt4_unauthorized,
@@ -113,8 +115,10 @@ func NewBolt4(serverName string, conn net.Conn, logger log.Logger, boltLog log.B
 		in: incoming{
 			buf: make([]byte, 4096),
 			hyd: hydrator{
-				boltLogger: boltLog,
-				boltMajor:  4,
+				boltLogger:  boltLog,
+				boltMajor:   4,
+				codecs:      valueConfig.Codecs,
+				lazyRecords: valueConfig.LazyRecords,
 			},
 			connReadTimeout: -1,
 			logger:          logger,
@@ -127,6 +131,7 @@ func NewBolt4(serverName string, conn net.Conn, logger log.Logger, boltLog log.B
 		packer:     packstream.Packer{},
 		onErr:      func(err error) { b.setError(err, true) },
 		boltLogger: boltLog,
+		codecs:     valueConfig.Codecs,
 	}
 
 	return b
@@ -225,10 +230,13 @@ func (b *bolt4) receiveSuccess(ctx context.Context) *success {
 	}
 }
 
-func (b *bolt4) Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, routingContext map[string]string) error {
+func (b *bolt4) Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, routingContext map[string]string, notificationConfig idb.NotificationConfig) error {
 	if err := b.assertState(bolt4_unauthorized); err != nil {
 		return err
 	}
+	if err := checkNotificationFiltering(notificationConfig, b.serverName); err != nil {
+		return err
+	}
 
 	// Prepare hello message
 	hello := map[string]interface{}{
@@ -267,6 +275,7 @@ func (b *bolt4) Connect(ctx context.Context, minor int, auth map[string]interfac
 	b.out.logId = connectionLogId
 
 	b.initializeReadTimeoutHint(succ.configurationHints)
+	b.auth = auth
 	// Transition into ready state
 	b.state = bolt4_ready
 	b.minor = minor
@@ -299,6 +308,9 @@ func (b *bolt4) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.TxHandl
 	if err := b.checkImpersonationAndVersion(txConfig.ImpersonatedUser); err != nil {
 		return 0, err
 	}
+	if err := checkNotificationFiltering(txConfig.NotificationConfig, b.serverName); err != nil {
+		return 0, err
+	}
 
 	tx := internalTx4{
 		mode:             txConfig.Mode,
@@ -565,6 +577,7 @@ func (b *bolt4) run(ctx context.Context, cypher string, params map[string]interf
 	}
 	// Append pull message and send it along with other pending messages
 	b.out.appendPullN(fetchSize)
+	runStart := time.Now()
 	b.out.send(ctx, b.conn)
 
 	// Receive confirmation of run message
@@ -574,6 +587,7 @@ func (b *bolt4) run(ctx context.Context, cypher string, params map[string]interf
 		// pull message as well, this will be cleaned up by Reset
 		return nil, b.err
 	}
+	b.runLatency = time.Since(runStart)
 	// Extract the RUN response from success response
 	b.tfirst = succ.tfirst
 	// Change state to streaming
@@ -600,6 +614,9 @@ func (b *bolt4) Run(ctx context.Context, cmd idb.Command,
 	if err := b.checkImpersonationAndVersion(txConfig.ImpersonatedUser); err != nil {
 		return 0, err
 	}
+	if err := checkNotificationFiltering(txConfig.NotificationConfig, b.serverName); err != nil {
+		return 0, err
+	}
 
 	tx := internalTx4{
 		mode:             txConfig.Mode,
@@ -844,6 +861,12 @@ func (b *bolt4) Reset(ctx context.Context) {
 	b.ForceReset(ctx)
 }
 
+func (b *bolt4) TakeRunLatency() time.Duration {
+	latency := b.runLatency
+	b.runLatency = 0
+	return latency
+}
+
 func (b *bolt4) ForceReset(ctx context.Context) {
 	if b.state == bolt4_dead {
 		return
@@ -985,6 +1008,18 @@ func (b *bolt4) SetBoltLogger(boltLogger log.BoltLogger) {
 	b.out.boltLogger = boltLogger
 }
 
+func (b *bolt4) IsAuthenticatedWith(auth map[string]interface{}) bool {
+	return authTokensEqual(b.auth, auth)
+}
+
+// ReAuth is only supported from Bolt 5.1 onwards, older versions can only keep their initial token
+func (b *bolt4) ReAuth(_ context.Context, auth *idb.ReAuthToken) error {
+	if b.IsAuthenticatedWith(auth.Token) {
+		return nil
+	}
+	return reAuthNotSupportedError(b.serverName)
+}
+
 func (b *bolt4) Version() db.ProtocolVersion {
 	return db.ProtocolVersion{
 		Major: 4,
@@ -103,7 +103,7 @@ func TestBolt4(outer *testing.T) {
 		tcpConn, srv, cleanup := setupBolt4Pipe(t)
 		go serverJob(srv)
 
-		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", nil, logger, nil)
+		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -186,7 +186,7 @@ func TestBolt4(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", routingContext, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -204,7 +204,7 @@ func TestBolt4(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -223,7 +223,7 @@ func TestBolt4(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", routingContext, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -238,7 +238,7 @@ func TestBolt4(outer *testing.T) {
 			srv.waitForHello()
 			srv.rejectHelloUnauthorized()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNil(t, bolt)
 		AssertError(t, err)
 		dbErr, isDbErr := err.(*db.Neo4jError)
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"net"
+	"runtime"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
@@ -45,13 +46,17 @@ const (
 // Default fetch size
 const bolt5FetchSize = 1000
 
+const telemetryEnabledHintName = "telemetry.enabled"
+
 type internalTx5 struct {
-	mode             idb.AccessMode
-	bookmarks        []string
-	timeout          time.Duration
-	txMeta           map[string]interface{}
-	databaseName     string
-	impersonatedUser string
+	mode               idb.AccessMode
+	bookmarks          []string
+	timeout            time.Duration
+	txMeta             map[string]interface{}
+	databaseName       string
+	impersonatedUser   string
+	notificationConfig idb.NotificationConfig
+	telemetryAPI       idb.TelemetryAPI
 }
 
 func (i *internalTx5) toMeta() map[string]interface{} {
@@ -75,9 +80,20 @@ func (i *internalTx5) toMeta() map[string]interface{} {
 	if i.impersonatedUser != "" {
 		meta["imp_user"] = i.impersonatedUser
 	}
+	appendNotificationConfig(meta, i.notificationConfig)
 	return meta
 }
 
+// Adds the notification filters to HELLO, BEGIN or RUN metadata, from 5.2 onwards
+func appendNotificationConfig(meta map[string]interface{}, config idb.NotificationConfig) {
+	if config.MinSeverity != "" {
+		meta["notifications_minimum_severity"] = config.MinSeverity
+	}
+	if config.DisabledCategories != nil {
+		meta["notifications_disabled_categories"] = config.DisabledCategories
+	}
+}
+
 type bolt5 struct {
 	state         int
 	txId          idb.TxHandle
@@ -89,8 +105,9 @@ type bolt5 struct {
 	connId        string
 	logId         string
 	serverVersion string
-	tfirst        int64  // Time that server started streaming
-	bookmark      string // Last bookmark
+	tfirst        int64         // Time that server started streaming
+	runLatency    time.Duration // Time between sending the last RUN and receiving its SUCCESS, until taken
+	bookmark      string        // Last bookmark
 	birthDate     time.Time
 	log           log.Logger
 	databaseName  string
@@ -98,9 +115,12 @@ type bolt5 struct {
 	minor         int
 	lastQid       int64 // Last seen qid
 	idleDate      time.Time
+	auth          map[string]interface{} // Current authentication token
+	telemetry     bool                   // Whether the server accepts TELEMETRY messages
+	agentProduct  string                 // Identifies the driver in the bolt_agent entry of HELLO, from 5.3 onwards
 }
 
-func NewBolt5(serverName string, conn net.Conn, logger log.Logger, boltLog log.BoltLogger) *bolt5 {
+func NewBolt5(serverName string, conn net.Conn, agentProduct string, valueConfig ValueConfig, logger log.Logger, boltLog log.BoltLogger) *bolt5 {
 	now := time.Now()
 	b := &bolt5{
 		state:      bolt5Unauthorized,
@@ -113,20 +133,24 @@ func NewBolt5(serverName string, conn net.Conn, logger log.Logger, boltLog log.B
 		in: incoming{
 			buf: make([]byte, 4096),
 			hyd: hydrator{
-				boltLogger: boltLog,
-				boltMajor:  5,
+				boltLogger:  boltLog,
+				boltMajor:   5,
+				codecs:      valueConfig.Codecs,
+				lazyRecords: valueConfig.LazyRecords,
 			},
 			connReadTimeout: -1,
 			logger:          logger,
 			logName:         log.Bolt5,
 		},
-		lastQid: -1,
+		lastQid:      -1,
+		agentProduct: agentProduct,
 	}
 	b.out = outgoing{
 		chunker:    newChunker(),
 		packer:     packstream.Packer{},
 		onErr:      func(err error) { b.setError(err, true) },
 		boltLogger: boltLog,
+		codecs:     valueConfig.Codecs,
 	}
 
 	return b
@@ -225,10 +249,15 @@ func (b *bolt5) receiveSuccess(ctx context.Context) *success {
 	}
 }
 
-func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, routingContext map[string]string) error {
+func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, routingContext map[string]string, notificationConfig idb.NotificationConfig) error {
 	if err := b.assertState(bolt5Unauthorized); err != nil {
 		return err
 	}
+	if minor < 2 {
+		if err := checkNotificationFiltering(notificationConfig, b.serverName); err != nil {
+			return err
+		}
+	}
 
 	// Prepare hello message
 	hello := map[string]interface{}{
@@ -237,24 +266,41 @@ func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]interfac
 	if routingContext != nil {
 		hello["routing"] = routingContext
 	}
-	// Merge authentication keys into hello, avoid overwriting existing keys
-	for k, v := range auth {
-		_, exists := hello[k]
-		if !exists {
-			hello[k] = v
+	if minor >= 3 {
+		hello["bolt_agent"] = boltAgent(b.agentProduct)
+	}
+	appendNotificationConfig(hello, notificationConfig)
+	// From 5.1 onwards, authentication is performed by a separate LOGON message
+	if minor < 1 {
+		// Merge authentication keys into hello, avoid overwriting existing keys
+		for k, v := range auth {
+			_, exists := hello[k]
+			if !exists {
+				hello[k] = v
+			}
 		}
 	}
 
 	// Send hello message and wait for confirmation
 	b.out.appendHello(hello)
+	if minor >= 1 {
+		b.out.appendLogon(auth)
+	}
 	b.out.send(ctx, b.conn)
 	succ := b.receiveSuccess(ctx)
 	if b.err != nil {
 		return b.err
 	}
-
+	// The hydrator reuses the same success instance, keep what is needed before receiving the next one
 	b.connId = succ.connectionId
 	b.serverVersion = succ.server
+	hints := succ.configurationHints
+	if minor >= 1 {
+		if b.receiveSuccess(ctx); b.err != nil {
+			return b.err
+		}
+	}
+	b.auth = auth
 
 	// Construct log identity
 	connectionLogId := fmt.Sprintf("%s@%s", b.connId, b.serverName)
@@ -263,7 +309,10 @@ func (b *bolt5) Connect(ctx context.Context, minor int, auth map[string]interfac
 	b.in.logId = connectionLogId
 	b.out.logId = connectionLogId
 
-	b.initializeReadTimeoutHint(succ.configurationHints)
+	b.initializeReadTimeoutHint(hints)
+	if minor >= 4 {
+		b.initializeTelemetryHint(hints)
+	}
 	// Transition into ready state
 	b.state = bolt5Ready
 	b.minor = minor
@@ -286,18 +335,29 @@ func (b *bolt5) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.
 	if err := b.assertState(bolt5Ready); err != nil {
 		return 0, err
 	}
+	if err := b.checkNotificationFiltering(txConfig.NotificationConfig); err != nil {
+		return 0, err
+	}
 
 	tx := internalTx5{
-		mode:             txConfig.Mode,
-		bookmarks:        txConfig.Bookmarks,
-		timeout:          txConfig.Timeout,
-		txMeta:           txConfig.Meta,
-		databaseName:     b.databaseName,
-		impersonatedUser: txConfig.ImpersonatedUser,
+		mode:               txConfig.Mode,
+		bookmarks:          txConfig.Bookmarks,
+		timeout:            txConfig.Timeout,
+		txMeta:             txConfig.Meta,
+		databaseName:       b.databaseName,
+		impersonatedUser:   txConfig.ImpersonatedUser,
+		notificationConfig: txConfig.NotificationConfig,
+		telemetryAPI:       txConfig.TelemetryAPI,
 	}
 
+	telemetrySent := b.appendTelemetry(tx.telemetryAPI)
 	b.out.appendBegin(tx.toMeta())
 	b.out.send(ctx, b.conn)
+	if telemetrySent {
+		if b.receiveSuccess(ctx); b.err != nil {
+			return 0, b.err
+		}
+	}
 	b.receiveSuccess(ctx)
 	if b.err != nil {
 		return 0, b.err
@@ -307,6 +367,13 @@ func (b *bolt5) TxBegin(ctx context.Context, txConfig idb.TxConfig) (idb.
 	return b.txId, nil
 }
 
+func (b *bolt5) checkNotificationFiltering(config idb.NotificationConfig) error {
+	if b.minor >= 2 {
+		return nil
+	}
+	return checkNotificationFiltering(config, b.serverName)
+}
+
 // Should NOT set b.err or change b.state as this is used to guard against
 // misuse from clients that stick to their connections when they shouldn't.
 func (b *bolt5) assertTxHandle(h1, h2 idb.TxHandle) error {
@@ -536,8 +603,10 @@ func (b *bolt5) run(ctx context.Context, cypher string, params map[string]interf
 
 	// Transaction metadata, used either in lazily started transaction or to run message.
 	var meta map[string]interface{}
+	telemetrySent := false
 	if tx != nil {
 		meta = tx.toMeta()
+		telemetrySent = b.appendTelemetry(tx.telemetryAPI)
 	}
 
 	// Append run message
@@ -552,8 +621,15 @@ func (b *bolt5) run(ctx context.Context, cypher string, params map[string]interf
 	}
 	// Append pull message and send it along with other pending messages
 	b.out.appendPullN(fetchSize)
+	runStart := time.Now()
 	b.out.send(ctx, b.conn)
 
+	// Receive confirmation of telemetry message, if any
+	if telemetrySent {
+		if b.receiveSuccess(ctx); b.err != nil {
+			return nil, b.err
+		}
+	}
 	// Receive confirmation of run message
 	succ := b.receiveSuccess(ctx)
 	if b.err != nil {
@@ -561,6 +637,7 @@ func (b *bolt5) run(ctx context.Context, cypher string, params map[string]interf
 		// pull message as well, this will be cleaned up by Reset
 		return nil, b.err
 	}
+	b.runLatency = time.Since(runStart)
 	// Extract the RUN response from success response
 	b.tfirst = succ.tfirst
 	// Change state to streaming
@@ -583,14 +660,19 @@ func (b *bolt5) Run(ctx context.Context, cmd idb.Command,
 	if err := b.assertState(bolt5Streaming, bolt5Ready); err != nil {
 		return nil, err
 	}
+	if err := b.checkNotificationFiltering(txConfig.NotificationConfig); err != nil {
+		return nil, err
+	}
 
 	tx := internalTx5{
-		mode:             txConfig.Mode,
-		bookmarks:        txConfig.Bookmarks,
-		timeout:          txConfig.Timeout,
-		txMeta:           txConfig.Meta,
-		databaseName:     b.databaseName,
-		impersonatedUser: txConfig.ImpersonatedUser,
+		mode:               txConfig.Mode,
+		bookmarks:          txConfig.Bookmarks,
+		timeout:            txConfig.Timeout,
+		txMeta:             txConfig.Meta,
+		databaseName:       b.databaseName,
+		impersonatedUser:   txConfig.ImpersonatedUser,
+		notificationConfig: txConfig.NotificationConfig,
+		telemetryAPI:       txConfig.TelemetryAPI,
 	}
 	stream, err := b.run(ctx, cmd.Cypher, cmd.Params, cmd.FetchSize, &tx)
 	if err != nil {
@@ -827,6 +909,12 @@ func (b *bolt5) Reset(ctx context.Context) {
 	b.ForceReset(ctx)
 }
 
+func (b *bolt5) TakeRunLatency() time.Duration {
+	latency := b.runLatency
+	b.runLatency = 0
+	return latency
+}
+
 func (b *bolt5) ForceReset(ctx context.Context) {
 	if b.state == bolt5Dead {
 		return
@@ -908,6 +996,35 @@ func (b *bolt5) SetBoltLogger(boltLogger log.BoltLogger) {
 	b.out.boltLogger = boltLogger
 }
 
+func (b *bolt5) IsAuthenticatedWith(auth map[string]interface{}) bool {
+	return authTokensEqual(b.auth, auth)
+}
+
+func (b *bolt5) ReAuth(ctx context.Context, auth *idb.ReAuthToken) error {
+	if b.IsAuthenticatedWith(auth.Token) {
+		return nil
+	}
+	if b.minor < 1 {
+		return reAuthNotSupportedError(b.serverName)
+	}
+	if err := b.assertState(bolt5Ready); err != nil {
+		return err
+	}
+
+	b.log.Debugf(log.Bolt5, b.logId, "Re-authenticating connection")
+	b.out.appendLogoff()
+	b.out.appendLogon(auth.Token)
+	b.out.send(ctx, b.conn)
+	if b.receiveSuccess(ctx); b.err != nil {
+		return b.err
+	}
+	if b.receiveSuccess(ctx); b.err != nil {
+		return b.err
+	}
+	b.auth = auth.Token
+	return nil
+}
+
 func (b *bolt5) Version() db.ProtocolVersion {
 	return db.ProtocolVersion{
 		Major: 5,
@@ -915,6 +1032,50 @@ func (b *bolt5) Version() db.ProtocolVersion {
 	}
 }
 
+// Appends a TELEMETRY message if the server has enabled telemetry.
+// Returns true when the message has been appended and its response must be received.
+func (b *bolt5) appendTelemetry(api idb.TelemetryAPI) bool {
+	if !b.telemetry {
+		return false
+	}
+	var wireApi int
+	switch api {
+	case idb.TelemetryManagedTransaction:
+		wireApi = 0
+	case idb.TelemetryUnmanagedTransaction:
+		wireApi = 1
+	case idb.TelemetryAutoCommitTransaction:
+		wireApi = 2
+	case idb.TelemetryExecuteQuery:
+		wireApi = 3
+	default:
+		return false
+	}
+	b.out.appendTelemetry(wireApi)
+	return true
+}
+
+func (b *bolt5) initializeTelemetryHint(hints map[string]interface{}) {
+	telemetryHint, ok := hints[telemetryEnabledHintName]
+	if !ok {
+		return
+	}
+	enabled, ok := telemetryHint.(bool)
+	if !ok {
+		b.log.Infof(log.Bolt5, b.logId, `invalid %q value: %v, ignoring hint. Only boolean values are accepted`, telemetryEnabledHintName, telemetryHint)
+		return
+	}
+	b.telemetry = enabled
+}
+
+func boltAgent(product string) map[string]interface{} {
+	return map[string]interface{}{
+		"product":  product,
+		"platform": fmt.Sprintf("%s; %s", runtime.GOOS, runtime.GOARCH),
+		"language": fmt.Sprintf("Go/%s", runtime.Version()),
+	}
+}
+
 func (b *bolt5) initializeReadTimeoutHint(hints map[string]interface{}) {
 	readTimeoutHint, ok := hints[readTimeoutHintName]
 	if !ok {
@@ -103,7 +103,7 @@ func TestBolt5(outer *testing.T) {
 		tcpConn, srv, cleanup := setupBolt5Pipe(t)
 		go serverJob(srv)
 
-		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", nil, logger, nil)
+		c, err := Connect(context.Background(), "serverName", tcpConn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -185,7 +185,7 @@ func TestBolt5(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", routingContext, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", routingContext, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -203,7 +203,7 @@ func TestBolt5(outer *testing.T) {
 			}
 			srv.acceptHello()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNoError(t, err)
 		bolt.Close(context.Background())
 	})
@@ -218,7 +218,7 @@ func TestBolt5(outer *testing.T) {
 			srv.waitForHello()
 			srv.rejectHelloUnauthorized()
 		}()
-		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", nil, logger, nil)
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
 		AssertNil(t, bolt)
 		AssertError(t, err)
 		dbErr, isDbErr := err.(*db.Neo4jError)
@@ -230,6 +230,287 @@ func TestBolt5(outer *testing.T) {
 		}
 	})
 
+	outer.Run("Authenticates with LOGON from 5.1", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 1)
+			hello := srv.waitForHello()
+			if _, exists := hello["credentials"]; exists {
+				panic("Should be no credentials in hello")
+			}
+			srv.send(msgSuccess, map[string]interface{}{"connection_id": "cid", "server": "fake/5.1"})
+			AssertDeepEquals(t, srv.waitForLogon(), auth)
+			srv.acceptLogon()
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		AssertTrue(t, bolt.IsAuthenticatedWith(auth))
+	})
+
+	outer.Run("Failed authentication with LOGON", func(t *testing.T) {
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		defer conn.Close()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 1)
+			srv.waitForHello()
+			srv.send(msgSuccess, map[string]interface{}{"connection_id": "cid", "server": "fake/5.1"})
+			srv.waitForLogon()
+			srv.rejectHelloUnauthorized()
+		}()
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
+		AssertNil(t, bolt)
+		dbErr, isDbErr := err.(*db.Neo4jError)
+		AssertTrue(t, isDbErr)
+		AssertTrue(t, dbErr.IsAuthenticationFailed())
+	})
+
+	outer.Run("Re-authenticates with LOGOFF and LOGON", func(t *testing.T) {
+		newAuth := map[string]interface{}{"scheme": "bearer", "credentials": "token"}
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 1)
+			srv.waitForLogoff()
+			AssertDeepEquals(t, srv.waitForLogon(), newAuth)
+			srv.sendSuccess(map[string]interface{}{})
+			srv.sendSuccess(map[string]interface{}{})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		err := bolt.ReAuth(context.Background(), &idb.ReAuthToken{Token: newAuth, FromSession: true})
+
+		AssertNoError(t, err)
+		AssertTrue(t, bolt.IsAuthenticatedWith(newAuth))
+		AssertFalse(t, bolt.IsAuthenticatedWith(auth))
+		assertBoltState(t, bolt5Ready, bolt)
+	})
+
+	outer.Run("Does not re-authenticate with same token", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 1)
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		err := bolt.ReAuth(context.Background(), &idb.ReAuthToken{Token: auth})
+
+		AssertNoError(t, err)
+	})
+
+	outer.Run("Re-authentication is not supported before 5.1", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 0)
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		err := bolt.ReAuth(context.Background(), &idb.ReAuthToken{Token: map[string]interface{}{"scheme": "none"}})
+
+		_, isFeatureNotSupported := err.(*db.FeatureNotSupportedError)
+		AssertTrue(t, isFeatureNotSupported)
+		AssertTrue(t, bolt.IsAuthenticatedWith(auth))
+		AssertTrue(t, bolt.IsAlive())
+	})
+
+	outer.Run("Sends notification filters in hello from 5.2", func(t *testing.T) {
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 2)
+			hello := srv.waitForHello()
+			AssertStringEqual(t, hello["notifications_minimum_severity"].(string), "WARNING")
+			AssertDeepEquals(t, hello["notifications_disabled_categories"], []interface{}{"HINT", "DEPRECATION"})
+			srv.acceptHello()
+		}()
+		notificationConfig := idb.NotificationConfig{MinSeverity: "WARNING", DisabledCategories: []string{"HINT", "DEPRECATION"}}
+
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, notificationConfig, ValueConfig{}, logger, nil)
+
+		AssertNoError(t, err)
+		bolt.Close(context.Background())
+	})
+
+	outer.Run("Notification filtering is not supported before 5.2", func(t *testing.T) {
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		defer conn.Close()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 1)
+		}()
+		notificationConfig := idb.NotificationConfig{MinSeverity: "OFF"}
+
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, notificationConfig, ValueConfig{}, logger, nil)
+
+		AssertNil(t, bolt)
+		_, isFeatureNotSupported := err.(*db.FeatureNotSupportedError)
+		AssertTrue(t, isFeatureNotSupported)
+	})
+
+	outer.Run("Sends notification filters in begin", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 2)
+			meta := srv.waitForTxBegin()
+			AssertStringEqual(t, meta["notifications_minimum_severity"].(string), "OFF")
+			AssertDeepEquals(t, meta["notifications_disabled_categories"], []interface{}{})
+			srv.sendSuccess(map[string]interface{}{})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		_, err := bolt.TxBegin(context.Background(), idb.TxConfig{
+			Mode:               idb.WriteMode,
+			NotificationConfig: idb.NotificationConfig{MinSeverity: "OFF", DisabledCategories: []string{}},
+		})
+
+		AssertNoError(t, err)
+	})
+
+	outer.Run("Sends notification filters in auto-commit run", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 2)
+			srv.serveRun(runResponse, func(fields []interface{}) {
+				meta := fields[2].(map[string]interface{})
+				AssertDeepEquals(t, meta["notifications_disabled_categories"], []interface{}{"GENERIC"})
+				_, hasMinSeverity := meta["notifications_minimum_severity"]
+				AssertFalse(t, hasMinSeverity)
+			})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		_, err := bolt.Run(context.Background(), idb.Command{Cypher: "RETURN 1"}, idb.TxConfig{
+			Mode:               idb.WriteMode,
+			NotificationConfig: idb.NotificationConfig{DisabledCategories: []string{"GENERIC"}},
+		})
+
+		AssertNoError(t, err)
+	})
+
+	outer.Run("Rejects notification filters in transactions before 5.2", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 1)
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		_, err := bolt.TxBegin(context.Background(), idb.TxConfig{
+			Mode:               idb.WriteMode,
+			NotificationConfig: idb.NotificationConfig{MinSeverity: "WARNING"},
+		})
+
+		_, isFeatureNotSupported := err.(*db.FeatureNotSupportedError)
+		AssertTrue(t, isFeatureNotSupported)
+		AssertTrue(t, bolt.IsAlive())
+	})
+
+	outer.Run("Sends bolt agent in hello from 5.3", func(t *testing.T) {
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 3)
+			hello := srv.waitForHello()
+			boltAgent := hello["bolt_agent"].(map[string]interface{})
+			AssertStringEqual(t, boltAgent["product"].(string), "neo4j-go/test")
+			AssertStringContain(t, boltAgent["language"].(string), "Go/")
+			AssertStringEqual(t, hello["user_agent"].(string), "007")
+			srv.acceptHello()
+		}()
+
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
+
+		AssertNoError(t, err)
+		bolt.Close(context.Background())
+	})
+
+	outer.Run("Does not send bolt agent in hello before 5.3", func(t *testing.T) {
+		conn, srv, cleanup := setupBolt5Pipe(t)
+		defer cleanup()
+		go func() {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 2)
+			hello := srv.waitForHello()
+			_, hasBoltAgent := hello["bolt_agent"]
+			AssertFalse(t, hasBoltAgent)
+			srv.acceptHello()
+		}()
+
+		bolt, err := Connect(context.Background(), "serverName", conn, auth, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, ValueConfig{}, logger, nil)
+
+		AssertNoError(t, err)
+		bolt.Close(context.Background())
+	})
+
+	outer.Run("Sends telemetry before begin when enabled by the server", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 4)
+			srv.waitForHello()
+			srv.acceptHelloWithHints(map[string]interface{}{"telemetry.enabled": true})
+			AssertIntEqual(t, srv.waitForTelemetry(), 1)
+			srv.waitForTxBegin()
+			srv.sendSuccess(map[string]interface{}{})
+			srv.sendSuccess(map[string]interface{}{})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		_, err := bolt.TxBegin(context.Background(), idb.TxConfig{
+			Mode:         idb.WriteMode,
+			TelemetryAPI: idb.TelemetryUnmanagedTransaction,
+		})
+
+		AssertNoError(t, err)
+		AssertIntEqual(t, bolt.state, bolt5Tx)
+	})
+
+	outer.Run("Sends telemetry before auto-commit run when enabled by the server", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.waitForHandshake()
+			srv.acceptVersion(5, 4)
+			srv.waitForHello()
+			srv.acceptHelloWithHints(map[string]interface{}{"telemetry.enabled": true})
+			AssertIntEqual(t, srv.waitForTelemetry(), 3)
+			srv.waitForRun(nil)
+			srv.waitForPullN(bolt5FetchSize)
+			srv.sendSuccess(map[string]interface{}{})
+			for _, x := range runResponse {
+				srv.send(x.tag, x.fields...)
+			}
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		stream, err := bolt.Run(context.Background(), idb.Command{Cypher: "RETURN 1"}, idb.TxConfig{
+			Mode:         idb.WriteMode,
+			TelemetryAPI: idb.TelemetryExecuteQuery,
+		})
+		AssertNoError(t, err)
+		_, err = bolt.Consume(context.Background(), stream)
+		AssertNoError(t, err)
+	})
+
+	outer.Run("Does not send telemetry unless enabled by the server", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.acceptWithMinor(5, 4)
+			srv.waitForTxBegin()
+			srv.sendSuccess(map[string]interface{}{})
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+
+		_, err := bolt.TxBegin(context.Background(), idb.TxConfig{
+			Mode:         idb.WriteMode,
+			TelemetryAPI: idb.TelemetryManagedTransaction,
+		})
+
+		AssertNoError(t, err)
+	})
+
 	outer.Run("Run auto-commit", func(t *testing.T) {
 		cypherText := "MATCH (n)"
 		theDb := "thedb"
@@ -257,6 +538,23 @@ func TestBolt5(outer *testing.T) {
 		assertBoltState(t, bolt5Ready, bolt)
 	})
 
+	outer.Run("Run measures the latency until the run response", func(t *testing.T) {
+		bolt, cleanup := connectToServer(t, func(srv *bolt5server) {
+			srv.accept(5)
+			srv.serveRun(runResponse, nil)
+		})
+		defer cleanup()
+		defer bolt.Close(context.Background())
+		AssertDeepEquals(t, bolt.TakeRunLatency(), time.Duration(0))
+
+		str, err := bolt.Run(context.Background(), idb.Command{Cypher: "MATCH (n)"}, idb.TxConfig{Mode: idb.ReadMode})
+		AssertNoError(t, err)
+		assertRunResponseOk(t, bolt, str)
+
+		AssertTrue(t, bolt.TakeRunLatency() > 0)
+		AssertDeepEquals(t, bolt.TakeRunLatency(), time.Duration(0))
+	})
+
 	outer.Run("Run auto-commit with impersonation", func(t *testing.T) {
 		cypherText := "MATCH (n)"
 		impersonatedUser := "a user"
@@ -38,6 +38,7 @@ type bolt5server struct {
 	conn     net.Conn
 	unpacker *packstream.Unpacker
 	out      *outgoing
+	minor    byte
 }
 
 func newBolt5Server(conn net.Conn) *bolt5server {
@@ -83,9 +84,9 @@ func (s *bolt5server) waitForHello() map[string]interface{} {
 	msg := s.receiveMsg()
 	s.assertStructType(msg, msgHello)
 	m := msg.fields[0].(map[string]interface{})
-	// Hello should contain some musts
+	// Hello should contain some musts, authentication is sent separately from 5.1 onwards
 	_, exists := m["scheme"]
-	if !exists {
+	if !exists && s.minor < 1 {
 		s.sendFailureMsg("?", "Missing scheme in hello")
 	}
 	_, exists = m["user_agent"]
@@ -95,6 +96,18 @@ func (s *bolt5server) waitForHello() map[string]interface{} {
 	return m
 }
 
+// Returns the authentication token
+func (s *bolt5server) waitForLogon() map[string]interface{} {
+	msg := s.receiveMsg()
+	s.assertStructType(msg, msgLogon)
+	return msg.fields[0].(map[string]interface{})
+}
+
+func (s *bolt5server) waitForLogoff() {
+	msg := s.receiveMsg()
+	s.assertStructType(msg, msgLogoff)
+}
+
 func (s *bolt5server) receiveMsg() *testStruct {
 	_, buf, err := dechunkMessage(context.Background(), s.conn, []byte{}, -1, log.Void{}, "", "")
 	if err != nil {
@@ -126,9 +139,18 @@ func (s *bolt5server) waitForReset() {
 	s.assertStructType(msg, msgReset)
 }
 
-func (s *bolt5server) waitForTxBegin() {
+// Returns the reported API
+func (s *bolt5server) waitForTelemetry() int {
+	msg := s.receiveMsg()
+	s.assertStructType(msg, msgTelemetry)
+	return int(msg.fields[0].(int64))
+}
+
+// Returns the begin metadata
+func (s *bolt5server) waitForTxBegin() map[string]interface{} {
 	msg := s.receiveMsg()
 	s.assertStructType(msg, msgBegin)
+	return msg.fields[0].(map[string]interface{})
 }
 
 func (s *bolt5server) waitForTxCommit() {
@@ -206,6 +228,7 @@ func (s *bolt5server) waitForRoute(assertRoute func(fields []interface{})) {
 }
 
 func (s *bolt5server) acceptVersion(major, minor byte) {
+	s.minor = minor
 	acceptedVer := []byte{0x00, 0x00, minor, major}
 	_, err := s.conn.Write(acceptedVer)
 	if err != nil {
@@ -238,6 +261,14 @@ func (s *bolt5server) acceptHello() {
 		"connection_id": "cid",
 		"server":        "fake/4.5",
 	})
+	if s.minor >= 1 {
+		s.waitForLogon()
+		s.acceptLogon()
+	}
+}
+
+func (s *bolt5server) acceptLogon() {
+	s.send(msgSuccess, map[string]interface{}{})
 }
 
 func (s *bolt5server) acceptHelloWithHints(hints map[string]interface{}) {
@@ -246,6 +277,10 @@ func (s *bolt5server) acceptHelloWithHints(hints map[string]interface{}) {
 		"server":        "fake/4.5",
 		"hints":         hints,
 	})
+	if s.minor >= 1 {
+		s.waitForLogon()
+		s.acceptLogon()
+	}
 }
 
 func (s *bolt5server) rejectHelloUnauthorized() {
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
 	"net"
@@ -31,6 +32,14 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
+// ValueConfig configures how query parameters are encoded and how record values are decoded
+type ValueConfig struct {
+	// Codecs holds custom encoders and decoders, nil when none are configured
+	Codecs *codec.Registry
+	// LazyRecords keeps the raw bytes of records and decodes their values on access
+	LazyRecords bool
+}
+
 type protocolVersion struct {
 	major byte
 	minor byte
@@ -39,7 +48,7 @@ type protocolVersion struct {
 
 // Supported versions in priority order
 var versions = [4]protocolVersion{
-	{major: 5, minor: 0},
+	{major: 5, minor: 4, back: 4},
 	{major: 4, minor: 4, back: 2},
 	{major: 4, minor: 1},
 	{major: 3, minor: 0},
@@ -47,7 +56,8 @@ var versions = [4]protocolVersion{
 
 // Connect initiates the negotiation of the Bolt protocol version.
 // Returns the instance of bolt protocol implementing the low-level Connection interface.
-func Connect(ctx context.Context, serverName string, conn net.Conn, auth map[string]interface{}, userAgent string, routingContext map[string]string, logger log.Logger, boltLog log.BoltLogger) (db.Connection, error) {
+// The bolt agent product identifies the driver to servers supporting Bolt 5.3 onwards.
+func Connect(ctx context.Context, serverName string, conn net.Conn, auth map[string]interface{}, userAgent string, boltAgentProduct string, routingContext map[string]string, notificationConfig db.NotificationConfig, valueConfig ValueConfig, logger log.Logger, boltLog log.BoltLogger) (db.Connection, error) {
 	// Perform Bolt handshake to negotiate version
 	// Send handshake to server
 	handshake := []byte{
@@ -82,17 +92,17 @@ func Connect(ctx context.Context, serverName string, conn net.Conn, auth map[str
 	var boltConn db.Connection
 	switch major {
 	case 3:
-		boltConn = NewBolt3(serverName, conn, logger, boltLog)
+		boltConn = NewBolt3(serverName, conn, valueConfig, logger, boltLog)
 	case 4:
-		boltConn = NewBolt4(serverName, conn, logger, boltLog)
+		boltConn = NewBolt4(serverName, conn, valueConfig, logger, boltLog)
 	case 5:
-		boltConn = NewBolt5(serverName, conn, logger, boltLog)
+		boltConn = NewBolt5(serverName, conn, boltAgentProduct, valueConfig, logger, boltLog)
 	case 0:
 		return nil, errors.New(fmt.Sprintf("Server did not accept any of the requested Bolt versions (%#v)", versions))
 	default:
 		return nil, errors.New(fmt.Sprintf("Server responded with unsupported version %d.%d", major, minor))
 	}
-	if err = boltConn.Connect(ctx, int(minor), auth, userAgent, routingContext); err != nil {
+	if err = boltConn.Connect(ctx, int(minor), auth, userAgent, routingContext, notificationConfig); err != nil {
 		return nil, err
 	}
 	return boltConn, nil
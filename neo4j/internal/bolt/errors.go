@@ -3,7 +3,11 @@ package bolt
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 )
 
 type ConnectionReadTimeout struct {
@@ -64,3 +68,27 @@ func IsTimeoutError(err error) bool {
 	timeoutErr, ok := err.(timeout)
 	return ok && timeoutErr.Timeout()
 }
+
+func reAuthNotSupportedError(serverName string) error {
+	return &db.FeatureNotSupportedError{
+		Server:  serverName,
+		Feature: "re-authentication",
+		Reason:  "requires Bolt 5.1 or later",
+	}
+}
+
+// Returns an error for protocol versions that cannot filter notifications, unless the server defaults are kept
+func checkNotificationFiltering(config idb.NotificationConfig, serverName string) error {
+	if config.IsDefault() {
+		return nil
+	}
+	return &db.FeatureNotSupportedError{
+		Server:  serverName,
+		Feature: "notification filtering",
+		Reason:  "requires Bolt 5.2 or later",
+	}
+}
+
+func authTokensEqual(token1, token2 map[string]interface{}) bool {
+	return reflect.DeepEqual(token1, token2)
+}
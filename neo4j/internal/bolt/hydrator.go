@@ -27,6 +27,7 @@ import (
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/packstream"
@@ -118,6 +119,8 @@ type hydrator struct {
 	boltLogger    log.BoltLogger
 	logId         string
 	boltMajor     int
+	codecs        *codec.Registry // Custom decoders, nil when none are configured
+	lazyRecords   bool
 }
 
 func (h *hydrator) setErr(err error) {
@@ -417,20 +420,79 @@ func (h *hydrator) record(n uint32) *db.Record {
 	if h.getErr() != nil {
 		return nil
 	}
-	rec := db.Record{}
 	h.unp.Next() // Detect array
 	n = h.unp.Len()
+	// Custom decoders and bolt logging need all values, they disable lazy decoding
+	if h.lazyRecords && h.boltLogger == nil && (h.codecs == nil || !h.codecs.HasDecoders()) {
+		return h.lazyRecord(int(n))
+	}
+	rec := db.Record{}
 	rec.Values = make([]interface{}, n)
 	for i := range rec.Values {
 		h.unp.Next()
 		rec.Values[i] = h.value()
 	}
+	if h.codecs != nil && h.codecs.HasDecoders() {
+		for i := range rec.Values {
+			rec.Values[i] = h.decode(rec.Values[i])
+		}
+	}
 	if h.boltLogger != nil {
 		h.boltLogger.LogServerMessage(h.logId, "RECORD %s", loggableList(rec.Values))
 	}
 	return &rec
 }
 
+// Copies the raw values of the record, to be decoded when accessed
+func (h *hydrator) lazyRecord(n int) *db.Record {
+	start := h.unp.Offset()
+	offsets := make([]uint32, n)
+	for i := range offsets {
+		offsets[i] = h.unp.Offset() - start
+		h.unp.Next()
+		h.unp.Skip()
+	}
+	if h.getErr() != nil {
+		return nil
+	}
+	raw := make([]byte, h.unp.Offset()-start)
+	copy(raw, h.unp.Raw(start, h.unp.Offset()))
+	boltMajor := h.boltMajor
+	return db.NewLazyRecord(n, func(i int) (interface{}, error) {
+		valueHydrator := hydrator{boltMajor: boltMajor}
+		valueHydrator.unp = &valueHydrator.unpacker
+		valueHydrator.unp.Reset(raw[offsets[i]:])
+		valueHydrator.unp.Next()
+		value := valueHydrator.value()
+		// The framing of the record is validated when skipping through it, values the driver
+		// cannot make sense of, such as unknown structs or malformed temporal values, fail here
+		if err := valueHydrator.getErr(); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+}
+
+// Applies the custom decoders to the value, as well as to the elements of lists and maps
+func (h *hydrator) decode(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		for i := range v {
+			v[i] = h.decode(v[i])
+		}
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = h.decode(item)
+		}
+	}
+	decoded, err := h.codecs.Decode(value)
+	if err != nil {
+		h.setErr(err)
+		return nil
+	}
+	return decoded
+}
+
 func (h *hydrator) value() interface{} {
 	valueType := h.unp.Curr
 	switch valueType {
@@ -883,6 +945,7 @@ func parseNotification(m map[string]interface{}) db.Notification {
 	n.Code, _ = m["code"].(string)
 	n.Description = m["description"].(string)
 	n.Severity, _ = m["severity"].(string)
+	n.Category, _ = m["category"].(string)
 	n.Title, _ = m["title"].(string)
 	posx, exists := m["position"].(map[string]interface{})
 	if exists {
@@ -20,12 +20,16 @@
 package bolt
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
@@ -859,3 +863,160 @@ func TestHydratorBolt5(ot *testing.T) {
 		})
 	}
 }
+
+func TestHydratorCodecs(ot *testing.T) {
+	registry := codec.NewRegistry()
+	codec.RegisterDecoder(registry, func(d dbtype.Duration) (interface{}, error) {
+		return time.Duration(d.Seconds)*time.Second + time.Duration(d.Nanos), nil
+	})
+	codec.RegisterDecoder(registry, func(s string) (interface{}, error) {
+		if s == "invalid" {
+			return nil, errors.New("invalid string")
+		}
+		return strings.ToUpper(s), nil
+	})
+	packer := packstream.Packer{}
+	hydrate := func() (interface{}, error) {
+		buf, err := packer.End()
+		if err != nil {
+			panic("Build error")
+		}
+		hydrator := hydrator{codecs: registry}
+		return hydrator.hydrate(buf)
+	}
+
+	ot.Run("Decodes record values, lists and maps", func(t *testing.T) {
+		packer.Begin([]byte{})
+		packer.StructHeader(byte(msgRecord), 1)
+		packer.ArrayHeader(4)
+		packer.StructHeader('E', 4)
+		packer.Int64(0)
+		packer.Int64(0)
+		packer.Int64(3)
+		packer.Int(4)
+		packer.Strings([]string{"a", "b"})
+		packer.MapHeader(1)
+		packer.String("key")
+		packer.String("value")
+		packer.Int64(1)
+
+		x, err := hydrate()
+
+		AssertNoError(t, err)
+		AssertDeepEquals(t, x, &db.Record{Values: []interface{}{
+			3*time.Second + 4,
+			[]interface{}{"A", "B"},
+			map[string]interface{}{"key": "VALUE"},
+			int64(1),
+		}})
+	})
+
+	ot.Run("Does not decode success metadata", func(t *testing.T) {
+		packer.Begin([]byte{})
+		packer.StructHeader(byte(msgSuccess), 1)
+		packer.MapHeader(1)
+		packer.String("server")
+		packer.String("srv")
+
+		x, err := hydrate()
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, x.(*success).server, "srv")
+	})
+
+	ot.Run("Fails when a decoder fails", func(t *testing.T) {
+		packer.Begin([]byte{})
+		packer.StructHeader(byte(msgRecord), 1)
+		packer.ArrayHeader(1)
+		packer.String("invalid")
+
+		_, err := hydrate()
+
+		AssertErrorMessageContains(t, err, "invalid string")
+	})
+}
+
+func TestHydratorLazyRecords(ot *testing.T) {
+	packer := packstream.Packer{}
+	buildRecord := func() []byte {
+		packer.Begin([]byte{})
+		packer.StructHeader(byte(msgRecord), 1)
+		packer.ArrayHeader(3)
+		packer.Int64(1)
+		packer.StructHeader('N', 4)
+		packer.Int64(2)
+		packer.ArrayHeader(1)
+		packer.String("Person")
+		packer.MapHeader(1)
+		packer.String("name")
+		packer.String("Alice")
+		packer.String("2")
+		packer.Strings([]string{"a", "b"})
+		buf, err := packer.End()
+		if err != nil {
+			panic("Build error")
+		}
+		return buf
+	}
+	keys := []string{"id", "n", "list"}
+
+	ot.Run("Decodes values on access", func(t *testing.T) {
+		eager := hydrator{boltMajor: 5}
+		x, err := eager.hydrate(buildRecord())
+		AssertNoError(t, err)
+		expected := x.(*db.Record)
+		expected.Keys = keys
+		lazy := hydrator{boltMajor: 5, lazyRecords: true}
+
+		x, err = lazy.hydrate(buildRecord())
+
+		AssertNoError(t, err)
+		record := x.(*db.Record)
+		record.Keys = keys
+		AssertNil(t, record.Values)
+		n, found := record.Get("n")
+		AssertTrue(t, found)
+		AssertDeepEquals(t, n, expected.Values[1])
+		AssertDeepEquals(t, record.AllValues(), expected.Values)
+	})
+
+	ot.Run("Reports values that cannot be decoded", func(t *testing.T) {
+		packer.Begin([]byte{})
+		packer.StructHeader(byte(msgRecord), 1)
+		packer.ArrayHeader(2)
+		packer.Int64(1)
+		packer.StructHeader('?', 1)
+		packer.Int64(2)
+		buf, err := packer.End()
+		AssertNoError(t, err)
+		lazy := hydrator{boltMajor: 5, lazyRecords: true}
+
+		x, err := lazy.hydrate(buf)
+
+		AssertNoError(t, err)
+		record := x.(*db.Record)
+		record.Keys = []string{"id", "unknown"}
+		id, _ := record.Get("id")
+		AssertDeepEquals(t, id, int64(1))
+		AssertNoError(t, record.Err())
+		unknown, found := record.Get("unknown")
+		AssertTrue(t, found)
+		AssertNil(t, unknown)
+		AssertError(t, record.Err())
+	})
+
+	ot.Run("Decodes values eagerly with a bolt logger", func(t *testing.T) {
+		lazy := hydrator{boltMajor: 5, lazyRecords: true, boltLogger: &silentBoltLogger{}}
+
+		x, err := lazy.hydrate(buildRecord())
+
+		AssertNoError(t, err)
+		AssertLen(t, x.(*db.Record).Values, 3)
+	})
+}
+
+type silentBoltLogger struct{}
+
+func (*silentBoltLogger) LogClientMessage(string, string, ...interface{}) {}
+
+func (*silentBoltLogger) LogServerMessage(string, string, ...interface{}) {}
@@ -38,4 +38,7 @@ const (
 	msgCommit     byte = 0x12
 	msgRollback   byte = 0x13
 	msgRoute      byte = 0x66 // > 4.2
+	msgLogon      byte = 0x6a // >= 5.1
+	msgLogoff     byte = 0x6b // >= 5.1
+	msgTelemetry  byte = 0x54 // >= 5.4
 )
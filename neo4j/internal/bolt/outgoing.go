@@ -21,7 +21,10 @@ package bolt
 
 import (
 	"context"
+	"encoding"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/structs"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 	"io"
 	"reflect"
@@ -38,6 +41,7 @@ type outgoing struct {
 	onErr      func(err error)
 	boltLogger log.BoltLogger
 	logId      string
+	codecs     *codec.Registry // Custom encoders, nil when none are configured
 }
 
 func (o *outgoing) begin() {
@@ -64,6 +68,35 @@ func (o *outgoing) appendHello(hello map[string]interface{}) {
 	o.end()
 }
 
+func (o *outgoing) appendLogon(token map[string]interface{}) {
+	if o.boltLogger != nil {
+		o.boltLogger.LogClientMessage(o.logId, "LOGON %s", loggableDictionary(token))
+	}
+	o.begin()
+	o.packer.StructHeader(byte(msgLogon), 1)
+	o.packMap(token)
+	o.end()
+}
+
+func (o *outgoing) appendLogoff() {
+	if o.boltLogger != nil {
+		o.boltLogger.LogClientMessage(o.logId, "LOGOFF")
+	}
+	o.begin()
+	o.packer.StructHeader(byte(msgLogoff), 0)
+	o.end()
+}
+
+func (o *outgoing) appendTelemetry(api int) {
+	if o.boltLogger != nil {
+		o.boltLogger.LogClientMessage(o.logId, "TELEMETRY %d", api)
+	}
+	o.begin()
+	o.packer.StructHeader(byte(msgTelemetry), 1)
+	o.packer.Int(api)
+	o.end()
+}
+
 func (o *outgoing) appendBegin(meta map[string]interface{}) {
 	if o.boltLogger != nil {
 		o.boltLogger.LogClientMessage(o.logId, "BEGIN %s", loggableDictionary(meta))
@@ -328,8 +361,65 @@ func (o *outgoing) packStruct(x interface{}) {
 		o.packer.Int64(v.Seconds)
 		o.packer.Int(v.Nanos)
 	default:
-		o.onErr(&db.UnsupportedTypeError{Type: reflect.TypeOf(x)})
+		if o.packTextMarshaler(x) {
+			return
+		}
+		o.packStructAsMap(reflect.Indirect(reflect.ValueOf(x)))
+	}
+}
+
+// Packs user defined structs as maps, keyed by field name or by the name in the neo4j struct tag
+func (o *outgoing) packStructAsMap(v reflect.Value) {
+	fields := structs.Fields(v.Type())
+	names := make([]string, 0, len(fields))
+	values := make([]reflect.Value, 0, len(fields))
+	for _, field := range fields {
+		value := v.FieldByIndex(field.Index)
+		if field.OmitEmpty && isEmptyValue(value) {
+			continue
+		}
+		names = append(names, field.Name)
+		values = append(values, value)
+	}
+	o.packer.MapHeader(len(names))
+	for i, name := range names {
+		o.packer.String(name)
+		o.packX(values[i].Interface())
+	}
+}
+
+// Packs types implementing encoding.TextMarshaler as strings, returns false if x does not implement it
+func (o *outgoing) packTextMarshaler(x interface{}) bool {
+	marshaler, ok := x.(encoding.TextMarshaler)
+	if !ok {
+		return false
 	}
+	text, err := marshaler.MarshalText()
+	if err != nil {
+		o.onErr(err)
+		return true
+	}
+	o.packer.String(string(text))
+	return true
+}
+
+// Same definition of empty as omitempty in encoding/json
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
 }
 
 func (o *outgoing) packX(x interface{}) {
@@ -337,6 +427,38 @@ func (o *outgoing) packX(x interface{}) {
 		o.packer.Nil()
 		return
 	}
+	if o.codecs != nil {
+		value, found, err := o.codecs.Encode(x)
+		if err != nil {
+			o.onErr(err)
+			return
+		}
+		if found {
+			if reflect.TypeOf(value) == reflect.TypeOf(x) {
+				o.onErr(&db.UnsupportedTypeError{Type: reflect.TypeOf(x)})
+				return
+			}
+			o.packX(value)
+			return
+		}
+	}
+	if marshaler, ok := x.(dbtype.ValueMarshaler); ok {
+		if v := reflect.ValueOf(x); v.Kind() == reflect.Ptr && v.IsNil() {
+			o.packer.Nil()
+			return
+		}
+		value, err := marshaler.MarshalNeo4j()
+		if err != nil {
+			o.onErr(err)
+			return
+		}
+		if _, ok := value.(dbtype.ValueMarshaler); ok {
+			o.onErr(&db.UnsupportedTypeError{Type: reflect.TypeOf(value)})
+			return
+		}
+		o.packX(value)
+		return
+	}
 
 	v := reflect.ValueOf(x)
 	switch v.Kind() {
@@ -408,6 +530,9 @@ func (o *outgoing) packX(x interface{}) {
 			}
 		}
 	default:
+		if o.packTextMarshaler(x) {
+			return
+		}
 		o.onErr(&db.UnsupportedTypeError{Type: reflect.TypeOf(x)})
 	}
 }
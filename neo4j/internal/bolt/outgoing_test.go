@@ -21,6 +21,8 @@ package bolt
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 	"net"
@@ -28,11 +30,53 @@ import (
 	"testing"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/packstream"
 )
 
+type paramAddress struct {
+	City string `neo4j:"city"`
+}
+
+type paramAudit struct {
+	Version int `neo4j:"version"`
+}
+
+type paramPerson struct {
+	Name     string        `neo4j:"name"`
+	Age      int           `neo4j:"age,omitempty"`
+	Address  *paramAddress `neo4j:"address,omitempty"`
+	Ignored  string        `neo4j:"-"`
+	Nickname string
+	internal string
+	paramAudit
+}
+
+type paramId [2]byte
+
+func (p paramId) MarshalText() ([]byte, error) {
+	return []byte(hex.EncodeToString(p[:])), nil
+}
+
+type paramTemperature int
+
+func (p paramTemperature) MarshalNeo4j() (interface{}, error) {
+	if p < -273 {
+		return nil, errors.New("below absolute zero")
+	}
+	return map[string]interface{}{"unit": "C", "degrees": int(p)}, nil
+}
+
+type paramCelsius struct {
+	Degrees int
+}
+
+func (p *paramCelsius) MarshalNeo4j() (interface{}, error) {
+	return p.Degrees, nil
+}
+
 // Utility to dehydrate/unpack
 func unpack(u *packstream.Unpacker) interface{} {
 	u.Next()
@@ -471,6 +515,52 @@ func TestOutgoing(ot *testing.T) {
 				"custom map of ints":  map[string]interface{}{"l": int64(1)},
 			},
 		},
+		{
+			name: "map of structs",
+			inp: map[string]interface{}{
+				"person": paramPerson{
+					Name:       "Alice",
+					Age:        42,
+					Address:    &paramAddress{City: "Malmö"},
+					Ignored:    "ignored",
+					paramAudit: paramAudit{Version: 3},
+				},
+				"empty person": &paramPerson{Name: "Bob"},
+			},
+			expect: map[string]interface{}{
+				"person": map[string]interface{}{
+					"name":     "Alice",
+					"age":      int64(42),
+					"address":  map[string]interface{}{"city": "Malmö"},
+					"Nickname": "",
+					"version":  int64(3),
+				},
+				"empty person": map[string]interface{}{
+					"name":     "Bob",
+					"Nickname": "",
+					"version":  int64(0),
+				},
+			},
+		},
+		{
+			name: "map of marshalers",
+			inp: map[string]interface{}{
+				"text":             paramId{0xca, 0xfe},
+				"value":            paramTemperature(21),
+				"text field":       struct{ Id paramId }{Id: paramId{0x01, 0x02}},
+				"nil value":        (*paramCelsius)(nil),
+				"value ptr":        &paramCelsius{Degrees: 3},
+				"time is not text": time.Unix(1, 2).UTC(),
+			},
+			expect: map[string]interface{}{
+				"text":             "cafe",
+				"value":            map[string]interface{}{"unit": "C", "degrees": int64(21)},
+				"text field":       map[string]interface{}{"Id": "0102"},
+				"nil value":        nil,
+				"value ptr":        int64(3),
+				"time is not text": &testStruct{tag: 'f', fields: []interface{}{int64(1), int64(2), "UTC"}},
+			},
+		},
 		{
 			name: "map of pointer types",
 			inp: map[string]interface{}{
@@ -497,7 +587,37 @@ func TestOutgoing(ot *testing.T) {
 		})
 	}
 
-	type aStruct struct{}
+	ot.Run("custom encoders", func(t *testing.T) {
+		registry := codec.NewRegistry()
+		codec.RegisterEncoder(registry, func(d time.Duration) (interface{}, error) {
+			return dbtype.Duration{Seconds: int64(d / time.Second), Nanos: int(d % time.Second)}, nil
+		})
+		codec.RegisterEncoder(registry, func(t paramTemperature) (interface{}, error) {
+			return int(t) * 2, nil
+		})
+		x := dechunkAndUnpack(t, func(out *outgoing) {
+			out.codecs = registry
+			out.begin()
+			out.packMap(map[string]interface{}{
+				"duration":    3*time.Second + 4,
+				"temperature": paramTemperature(21),
+				"durations":   []time.Duration{time.Second},
+			})
+			out.end()
+		})
+		expected := map[string]interface{}{
+			"duration":    &testStruct{tag: 'E', fields: []interface{}{int64(0), int64(0), int64(3), int64(4)}},
+			"temperature": int64(42),
+			"durations":   []interface{}{&testStruct{tag: 'E', fields: []interface{}{int64(0), int64(0), int64(1), int64(0)}}},
+		}
+		if !reflect.DeepEqual(x, expected) {
+			t.Errorf("Unpacked differs, expected\n %#v but was\n %#v", expected, x)
+		}
+	})
+
+	type aStruct struct {
+		C chan int
+	}
 
 	// Test packing of stuff that is expected to give an error
 	paramErrorCases := []struct {
@@ -526,6 +646,20 @@ func TestOutgoing(ot *testing.T) {
 			},
 			err: &db.UnsupportedTypeError{},
 		},
+		{
+			name: "an array",
+			inp: map[string]interface{}{
+				"m": [2]int{1, 2},
+			},
+			err: &db.UnsupportedTypeError{},
+		},
+		{
+			name: "a failing value marshaler",
+			inp: map[string]interface{}{
+				"m": paramTemperature(-300),
+			},
+			err: errors.New(""),
+		},
 	}
 	for _, c := range paramErrorCases {
 		var err error
@@ -27,11 +27,12 @@ import (
 // Parses a record assumed to contain a routing table into common DB API routing table struct
 // Returns nil if error while parsing
 func parseRoutingTableRecord(rec *db.Record) *idb.RoutingTable {
-	ttl, ok := rec.Values[0].(int64)
+	values := rec.AllValues()
+	ttl, ok := values[0].(int64)
 	if !ok {
 		return nil
 	}
-	listOfX, ok := rec.Values[1].([]interface{})
+	listOfX, ok := values[1].([]interface{})
 	if !ok {
 		return nil
 	}
@@ -28,8 +28,10 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"io"
 	"net"
+	"sync"
 	"time"
 
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/codec"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/bolt"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
@@ -47,9 +49,56 @@ type Connector struct {
 	RoutingContext  map[string]string
 	Network         string
 	TlsConfig       *tls.Config
+	// NotificationConfig is sent along with the HELLO message of every connection
+	NotificationConfig db.NotificationConfig
+	// Codecs holds the custom encoders and decoders of query parameters and record values
+	Codecs *codec.Registry
+	// LazyRecords defers the decoding of record values until they are accessed
+	LazyRecords bool
+	// BoltAgentProduct identifies the driver in the bolt agent, see bolt.Connect
+	BoltAgentProduct string
+	// ServerNames holds the host names of the addresses resolved by the driver, nil when none are resolved
+	ServerNames *ServerNames
 }
 
-func (c Connector) Connect(ctx context.Context, address string, boltLogger log.BoltLogger) (db.Connection, error) {
+// ServerNames maps the addresses resolved from host names back to these host names, so that TLS connections to
+// resolved addresses verify the certificate of, and indicate (SNI), the host name instead of the address.
+type ServerNames struct {
+	mut   sync.Mutex
+	hosts map[string]string
+}
+
+func NewServerNames() *ServerNames {
+	return &ServerNames{hosts: make(map[string]string)}
+}
+
+// Register records that address has been resolved from host, the last registered host of an address wins
+func (n *ServerNames) Register(address, host string) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	n.hosts[address] = host
+}
+
+// Lookup returns the host the address has been resolved from, if any
+func (n *ServerNames) Lookup(address string) (string, bool) {
+	n.mut.Lock()
+	defer n.mut.Unlock()
+	host, found := n.hosts[address]
+	return host, found
+}
+
+func (c Connector) valueConfig() bolt.ValueConfig {
+	return bolt.ValueConfig{Codecs: c.Codecs, LazyRecords: c.LazyRecords}
+}
+
+// Connect establishes a new connection to the provided address, authenticated with the provided token.
+// The default token of the connector is used when the token is nil.
+func (c Connector) Connect(ctx context.Context, address string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+	authToken := c.Auth
+	if auth != nil {
+		authToken = auth.Token
+	}
+
 	dialer := net.Dialer{Timeout: c.DialTimeout}
 	if !c.SocketKeepAlive {
 		dialer.KeepAlive = -1 * time.Second // Turns keep-alive off
@@ -62,7 +111,7 @@ func (c Connector) Connect(ctx context.Context, address string, boltLogger log.B
 
 	// TLS not requested, perform Bolt handshake
 	if c.SkipEncryption {
-		return bolt.Connect(ctx, address, conn, c.Auth, c.UserAgent, c.RoutingContext, c.Log, boltLogger)
+		return bolt.Connect(ctx, address, conn, authToken, c.UserAgent, c.BoltAgentProduct, c.RoutingContext, c.NotificationConfig, c.valueConfig(), c.Log, boltLogger)
 	}
 
 	// TLS requested, continue with handshake
@@ -71,6 +120,11 @@ func (c Connector) Connect(ctx context.Context, address string, boltLogger log.B
 		conn.Close()
 		return nil, err
 	}
+	if c.ServerNames != nil {
+		if host, found := c.ServerNames.Lookup(address); found {
+			serverName = host
+		}
+	}
 	tlsConn := tls.Client(conn, c.tlsConfig(serverName))
 	err = tlsConn.HandshakeContext(ctx)
 	if err != nil {
@@ -82,7 +136,7 @@ func (c Connector) Connect(ctx context.Context, address string, boltLogger log.B
 		return nil, &TlsError{inner: err}
 	}
 	// Perform Bolt handshake
-	return bolt.Connect(ctx, address, tlsConn, c.Auth, c.UserAgent, c.RoutingContext, c.Log, boltLogger)
+	return bolt.Connect(ctx, address, tlsConn, authToken, c.UserAgent, c.BoltAgentProduct, c.RoutingContext, c.NotificationConfig, c.valueConfig(), c.Log, boltLogger)
 }
 
 func (c Connector) tlsConfig(serverName string) *tls.Config {
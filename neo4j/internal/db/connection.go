@@ -48,18 +48,56 @@ type Command struct {
 }
 
 type TxConfig struct {
-	Mode             AccessMode
-	Bookmarks        []string
-	Timeout          time.Duration
-	ImpersonatedUser string
-	Meta             map[string]interface{}
+	Mode               AccessMode
+	Bookmarks          []string
+	Timeout            time.Duration
+	ImpersonatedUser   string
+	Meta               map[string]interface{}
+	NotificationConfig NotificationConfig
+	TelemetryAPI       TelemetryAPI
+}
+
+// TelemetryAPI identifies the driver API a transaction has been started with.
+// It is reported to servers that enable telemetry, from Bolt 5.4 onwards.
+type TelemetryAPI int
+
+const (
+	// TelemetryNone disables telemetry for the transaction
+	TelemetryNone TelemetryAPI = iota
+	TelemetryManagedTransaction
+	TelemetryUnmanagedTransaction
+	TelemetryAutoCommitTransaction
+	TelemetryExecuteQuery
+)
+
+// NotificationConfig filters the notifications the server sends, zero values leave the server defaults in place
+type NotificationConfig struct {
+	// MinSeverity is the minimum severity of the notifications, "OFF" disables all notifications
+	MinSeverity string
+	// DisabledCategories are the categories of the notifications not to send.
+	// A nil slice keeps the default categories whereas an empty slice enables all categories.
+	DisabledCategories []string
+}
+
+// IsDefault returns true if the configuration leaves the server defaults in place
+func (n NotificationConfig) IsDefault() bool {
+	return n.MinSeverity == "" && n.DisabledCategories == nil
 }
 
 const DefaultTxConfigTimeout = math.MinInt
 
+// ReAuthToken holds the authentication token a connection must be authenticated with before being used
+type ReAuthToken struct {
+	// Token is the authentication token, as sent to the server
+	Token map[string]interface{}
+	// FromSession is true when the token has been configured at the session level, instead of the driver level.
+	// Tokens of the driver level all stand for the driver user, for instance when caching home databases.
+	FromSession bool
+}
+
 // Connection defines an abstract database server connection.
 type Connection interface {
-	Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, routingContext map[string]string) error
+	Connect(ctx context.Context, minor int, auth map[string]interface{}, userAgent string, routingContext map[string]string, notificationConfig NotificationConfig) error
 
 	TxBegin(ctx context.Context, txConfig TxConfig) (TxHandle, error)
 	TxRollback(ctx context.Context, tx TxHandle) error
@@ -117,10 +155,25 @@ type Connection interface {
 	GetRoutingTable(ctx context.Context, context map[string]string, bookmarks []string, database, impersonatedUser string) (*RoutingTable, error)
 	// SetBoltLogger sets Bolt message logger on already initialized connections
 	SetBoltLogger(boltLogger log.BoltLogger)
+	// IsAuthenticatedWith returns true if the connection is currently authenticated with the provided token
+	IsAuthenticatedWith(auth map[string]interface{}) bool
+	// ReAuth makes sure the connection is authenticated with the provided token, re-authenticating the connection
+	// if it is currently authenticated with a different token.
+	// If the underlying protocol version does not support re-authentication and the token differs,
+	// a *db.FeatureNotSupportedError is returned and the connection should be closed instead.
+	ReAuth(ctx context.Context, auth *ReAuthToken) error
 	// Version returns the protocol version of the connection
 	Version() db.ProtocolVersion
 }
 
+// LatencyReporter is implemented by connections measuring how long the server takes to respond to queries.
+type LatencyReporter interface {
+	// TakeRunLatency returns the time between sending the last RUN message and receiving its SUCCESS response.
+	// Zero is returned when no RUN message has been answered since the previous call.
+	// Earlier RUN messages answered since the previous call are not reported.
+	TakeRunLatency() time.Duration
+}
+
 type RoutingTable struct {
 	TimeToLive   int
 	DatabaseName string
@@ -693,3 +693,51 @@ func TestPackStream(ot *testing.T) {
 		})
 	}
 }
+
+func TestUnpackerSkip(ot *testing.T) {
+	values := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "nil", value: nil},
+		{name: "bool", value: true},
+		{name: "tiny int", value: 7},
+		{name: "int64", value: int64(math.MaxInt64)},
+		{name: "float", value: 1.5},
+		{name: "string", value: "a string"},
+		{name: "bytes", value: []byte{1, 2, 3}},
+		{name: "list", value: []interface{}{1, "two", []interface{}{3.0}}},
+		{name: "map", value: map[string]interface{}{"a": 1, "b": []interface{}{"c"}}},
+		{name: "struct", value: &testStruct{tag: 0x4e, fields: []interface{}{1, []interface{}{"x"}, map[string]interface{}{"y": 2}}}},
+	}
+
+	for _, c := range values {
+		ot.Run(fmt.Sprintf("Skipping %s", c.name), func(t *testing.T) {
+			p := &Packer{}
+			p.Begin([]byte{})
+			pack(p, c.value)
+			p.Int(42)
+			buf, err := p.End()
+			if err != nil {
+				t.Fatalf("Unable to pack: %s", err)
+			}
+
+			u := &Unpacker{}
+			u.Reset(buf)
+			u.Next()
+			u.Skip()
+			skipped := u.Offset()
+			trailing := unpack(u)
+
+			if u.Err != nil {
+				t.Fatalf("Unable to skip: %s", u.Err)
+			}
+			if trailing != int64(42) {
+				t.Errorf("Expected trailing value 42 but was %v", trailing)
+			}
+			if raw := u.Raw(0, skipped); len(raw) != len(buf)-1 {
+				t.Errorf("Expected %d skipped bytes but was %d", len(buf)-1, len(raw))
+			}
+		})
+	}
+}
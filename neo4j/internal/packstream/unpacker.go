@@ -149,6 +149,50 @@ func (u *Unpacker) ByteArray() []byte {
 	return out
 }
 
+// Skip moves past the current value, nested values included, without decoding it
+func (u *Unpacker) Skip() {
+	switch u.Curr {
+	case PackedInt:
+		u.read(uint32(u.mrk.numlenbytes))
+	case PackedFloat:
+		u.read(8)
+	case PackedStr, PackedByteArray:
+		u.read(u.Len())
+	case PackedArray:
+		n := u.Len()
+		for i := uint32(0); i < n && u.Err == nil; i++ {
+			u.Next()
+			u.Skip()
+		}
+	case PackedMap:
+		n := u.Len()
+		for i := uint32(0); i < n*2 && u.Err == nil; i++ {
+			u.Next()
+			u.Skip()
+		}
+	case PackedStruct:
+		n := u.Len()
+		u.StructTag()
+		for i := uint32(0); i < n && u.Err == nil; i++ {
+			u.Next()
+			u.Skip()
+		}
+	case PackedNil, PackedTrue, PackedFalse:
+	default:
+		u.setErr(&UnpackError{msg: fmt.Sprintf("Illegal marker type: %d", u.Curr)})
+	}
+}
+
+// Offset returns the position of the unpacker in its buffer
+func (u *Unpacker) Offset() uint32 {
+	return u.off
+}
+
+// Raw returns the bytes of the buffer between start and end, the slice shares the buffer
+func (u *Unpacker) Raw(start, end uint32) []byte {
+	return u.buf[start:end]
+}
+
 func (u *Unpacker) pop() byte {
 	if u.off < u.len {
 		x := u.buf[u.off]
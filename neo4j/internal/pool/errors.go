@@ -26,6 +26,8 @@ import (
 type PoolTimeout struct {
 	err     error
 	servers []string
+	// The server the borrower waited on, a borrow among several servers times out on a single one
+	server string
 }
 
 func (e *PoolTimeout) Error() string {
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package pool
+
+import (
+	"sync"
+	"time"
+)
+
+// ServerMetrics is a snapshot of the connection pool metrics of a single server
+type ServerMetrics struct {
+	Idle                int64
+	InUse               int64
+	Creating            int64
+	Created             int64
+	Closed              int64
+	FailedToCreate      int64
+	Acquired            int64
+	AcquisitionTimeouts int64
+	// FailedLivenessChecks is the number of idle connections discarded because they failed the liveness check
+	FailedLivenessChecks int64
+	// AcquisitionTime is the accumulated time spent by borrowers until they acquired a connection
+	AcquisitionTime time.Duration
+}
+
+// Keeps track of the metrics of every server the pool has ever connected to.
+// Metrics have their own lock, so they can be read while the pool is busy connecting.
+// Thread safe
+type metrics struct {
+	mut     sync.Mutex
+	servers map[string]*ServerMetrics
+}
+
+func newMetrics() *metrics {
+	return &metrics{servers: make(map[string]*ServerMetrics)}
+}
+
+func (m *metrics) update(serverName string, update func(*ServerMetrics)) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	serverMetrics := m.servers[serverName]
+	if serverMetrics == nil {
+		serverMetrics = &ServerMetrics{}
+		m.servers[serverName] = serverMetrics
+	}
+	update(serverMetrics)
+}
+
+func (m *metrics) snapshot() map[string]ServerMetrics {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	result := make(map[string]ServerMetrics, len(m.servers))
+	for serverName, serverMetrics := range m.servers {
+		result[serverName] = *serverMetrics
+	}
+	return result
+}
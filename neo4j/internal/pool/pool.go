@@ -32,6 +32,7 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/racing"
 	"math"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
@@ -41,7 +42,23 @@ import (
 // Liveness checks are performed before a connection is deemed idle enough to be reset
 const DefaultLivenessCheckThreshold = math.MaxInt64
 
-type Connect func(context.Context, string, log.BoltLogger) (db.Connection, error)
+type Connect func(context.Context, string, *db.ReAuthToken, log.BoltLogger) (db.Connection, error)
+
+// ServerStats describes a server the pool can borrow a connection from
+type ServerStats struct {
+	Address string
+	// InUse is the number of connections to the server currently borrowed
+	InUse int
+	// Idle is the number of connections to the server ready to be borrowed
+	Idle int
+	// Latency is the moving average of the time the server takes to respond to queries, zero until measured
+	Latency time.Duration
+	// RecentlyFailed is true when connecting to the server has failed recently
+	RecentlyFailed bool
+}
+
+// SelectServer picks the address of the server to borrow a connection from, among at least one server
+type SelectServer func(servers []ServerStats) string
 
 type qitem struct {
 	servers []string
@@ -50,22 +67,37 @@ type qitem struct {
 }
 
 type Pool struct {
-	maxSize    int
-	maxAge     time.Duration
-	connect    Connect
-	servers    map[string]*server
-	serversMut racing.Mutex
-	queueMut   racing.Mutex
-	queue      list.List
-	now        func() time.Time
-	closed     bool
-	log        log.Logger
-	logId      string
+	maxSize     int
+	maxAge      time.Duration
+	maxIdleTime time.Duration
+	connect     Connect
+	servers     map[string]*server
+	serversMut  racing.Mutex
+	queueMut    racing.Mutex
+	queue       list.List
+	now         func() time.Time
+	closed      bool
+	log         log.Logger
+	logId       string
+	metrics     *metrics
+	// Servers the pool maintains minIdle idle connections to, see WarmUp
+	warmServers []string
+	minIdle     int
+	// Time given to replenish the idle connections of each warmed up server
+	replenishTimeout time.Duration
+	// Supplies the token replenished idle connections are authenticated with, nil means the connector's token
+	authToken func(context.Context) (*db.ReAuthToken, error)
+	// Replaces the penalty based selection of servers when set
+	selectServer SelectServer
+	replenish    chan struct{}
+	stop         chan struct{}
+	stopOnce     sync.Once
 }
 
 type serverPenalty struct {
 	name    string
 	penalty uint32
+	stats   ServerStats
 }
 
 func New(maxSize int, maxAge time.Duration, connect Connect, logger log.Logger, logId string) *Pool {
@@ -84,13 +116,156 @@ func New(maxSize int, maxAge time.Duration, connect Connect, logger log.Logger,
 		now:        time.Now,
 		logId:      logId,
 		log:        logger,
+		metrics:    newMetrics(),
+		replenish:  make(chan struct{}, 1),
+		stop:       make(chan struct{}),
 	}
 	p.log.Infof(log.Pool, p.logId, "Created")
 	return p
 }
 
+// Time given to replenish the idle connections of each server when no positive timeout is configured
+const defaultReplenishTimeout = 1 * time.Minute
+
+// SetMinIdle makes the pool maintain at least minIdle idle connections to each of the servers it has been
+// warmed up against, see WarmUp. Idle connections are replenished by a background goroutine whenever
+// connections to these servers are evicted, the goroutine stops when the pool is closed.
+// Replenishing the connections of a server is given timeout, or defaultReplenishTimeout when not positive.
+func (p *Pool) SetMinIdle(minIdle int, timeout time.Duration) {
+	if minIdle <= 0 || p.minIdle > 0 {
+		return
+	}
+	if timeout <= 0 {
+		timeout = defaultReplenishTimeout
+	}
+	p.minIdle = minIdle
+	p.replenishTimeout = timeout
+	go p.replenishIdle()
+}
+
+// SetAuthTokenSupplier makes the pool authenticate the idle connections it replenishes with the token returned by
+// supplier, fetched every time connections are replenished. By default, the connector's token is used.
+func (p *Pool) SetAuthTokenSupplier(supplier func(context.Context) (*db.ReAuthToken, error)) {
+	p.authToken = supplier
+}
+
+// SetSelectServer makes the pool borrow connections from the server picked by selectServer first, the other
+// servers are tried next, ordered by penalty. By default, servers are ordered by penalty only.
+func (p *Pool) SetSelectServer(selectServer SelectServer) {
+	p.selectServer = selectServer
+}
+
+// SetMaxIdleTime makes the pool close connections that have been idle for maxIdleTime, either when looking for
+// a connection to borrow or during housekeeping. Values less than or equal to 0 keep idle connections open.
+func (p *Pool) SetMaxIdleTime(maxIdleTime time.Duration) {
+	if maxIdleTime > 0 {
+		p.maxIdleTime = maxIdleTime
+	}
+}
+
+// StartHousekeeping starts a goroutine maintaining the pooled connections every interval, see housekeep.
+// knownServers returns the servers of all routing tables, it is nil when the pool is not used for routing.
+// The goroutine stops when the pool is closed.
+func (p *Pool) StartHousekeeping(interval time.Duration, knownServers func(context.Context) ([]string, error)) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				if err := p.housekeep(ctx, interval, knownServers); err != nil {
+					p.log.Warnf(log.Pool, p.logId, "Housekeeping failed: %s", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// Closes idle connections exceeding their maximum age or idle time, as well as the ones to servers no longer
+// part of any routing table, and pings the idle connections without traffic for pingInterval with a RESET.
+// Pinging keeps idle connections from being dropped by firewalls and detects dead connections before they are
+// borrowed.
+func (p *Pool) housekeep(ctx context.Context, pingInterval time.Duration, knownServers func(context.Context) ([]string, error)) error {
+	var known map[string]bool
+	if knownServers != nil {
+		serverNames, err := knownServers(ctx)
+		if err != nil {
+			return err
+		}
+		known = make(map[string]bool, len(serverNames))
+		for _, serverName := range serverNames {
+			known[serverName] = true
+		}
+	}
+
+	if !p.serversMut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire server lock in time when housekeeping")
+	}
+	// Connections are closed in the background, after ctx is done
+	closeCtx := context.Background()
+	now := p.now()
+	toPing := make(map[string][]db.Connection)
+	for _, warmServer := range p.warmServers {
+		if known != nil {
+			known[warmServer] = true
+		}
+	}
+	for n, s := range p.servers {
+		removed := s.removeIdleOlderThan(closeCtx, now, p.maxAge)
+		if p.maxIdleTime > 0 {
+			removed += s.removeIdleLongerThan(closeCtx, now, p.maxIdleTime)
+		}
+		if known != nil && !known[n] {
+			p.log.Infof(log.Pool, p.logId, "Removing idle connections to %s, no longer part of any routing table", n)
+			s.removeAllIdle(closeCtx)
+		} else if removed > 0 {
+			p.notifyEvicted()
+		}
+		if s.size() == 0 && !s.hasFailedConnect(now) {
+			delete(p.servers, n)
+			continue
+		}
+		if connections := s.takeIdleToPing(now.Add(-pingInterval)); len(connections) > 0 {
+			toPing[n] = connections
+		}
+	}
+	p.serversMut.Unlock()
+
+	for _, connections := range toPing {
+		for _, c := range connections {
+			c.ForceReset(ctx)
+		}
+	}
+
+	if !p.serversMut.TryLock(closeCtx) {
+		return racing.LockTimeoutError("could not acquire server lock in time when returning pinged connections")
+	}
+	defer p.serversMut.Unlock()
+	for n, connections := range toPing {
+		s := p.servers[n]
+		for _, c := range connections {
+			if s == nil || !s.returnPinged(c) {
+				p.log.Infof(log.Pool, p.logId, "Closing dead idle connection to %s", n)
+				p.notifyEvicted()
+				go c.Close(closeCtx)
+			}
+		}
+	}
+	return nil
+}
+
 func (p *Pool) Close(ctx context.Context) error {
 	p.closed = true
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
 	// Cancel everything in the queue by just emptying at and let all callers timeout
 	if !p.queueMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire queue lock in time when closing pool")
@@ -159,7 +334,9 @@ func (p *Pool) CleanUp(ctx context.Context) error {
 	defer p.serversMut.Unlock()
 	now := p.now()
 	for n, s := range p.servers {
-		s.removeIdleOlderThan(ctx, now, p.maxAge)
+		if s.removeIdleOlderThan(ctx, now, p.maxAge) > 0 {
+			p.notifyEvicted()
+		}
 		if s.size() == 0 && !s.hasFailedConnect(now) {
 			delete(p.servers, n)
 		}
@@ -180,26 +357,73 @@ func (p *Pool) getPenaltiesForServers(ctx context.Context, serverNames []string)
 		s := p.servers[n]
 		penalties[i].name = n
 		if s != nil {
-			// Make sure that we don't get a too old connection
-			s.removeIdleOlderThan(ctx, now, p.maxAge)
+			// Make sure that we don't get a too old or too long idle connection
+			removed := s.removeIdleOlderThan(ctx, now, p.maxAge)
+			if p.maxIdleTime > 0 {
+				removed += s.removeIdleLongerThan(ctx, now, p.maxIdleTime)
+			}
+			if removed > 0 {
+				p.notifyEvicted()
+			}
 			penalties[i].penalty = s.calculatePenalty(now)
+			penalties[i].stats = s.stats(now)
 		} else {
 			penalties[i].penalty = newConnectionPenalty
+			penalties[i].stats = ServerStats{Address: n}
 		}
 	}
 	return penalties, nil
 }
 
-func (p *Pool) tryAnyIdle(ctx context.Context, serverNames []string, idlenessThreshold time.Duration) (db.Connection, error) {
+func (p *Pool) selectAmong(penalties []serverPenalty) string {
+	stats := make([]ServerStats, len(penalties))
+	for i, s := range penalties {
+		stats[i] = s.stats
+	}
+	return p.selectServer(stats)
+}
+
+// Moves the selected server first, the other servers keep their order
+func preferServer(penalties []serverPenalty, selected string) {
+	for i, s := range penalties {
+		if s.name == selected {
+			copy(penalties[1:i+1], penalties[:i])
+			penalties[0] = s
+			return
+		}
+	}
+}
+
+func (p *Pool) tryAnyIdle(ctx context.Context, serverNames []string, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
+	for {
+		conn, err := p.takeAnyIdle(ctx, serverNames, idlenessThreshold, auth)
+		if conn == nil || err != nil {
+			return nil, err
+		}
+		// Re-authenticating requires a round trip to the server, the server lock is not held meanwhile
+		if p.reAuthenticate(ctx, conn, auth) {
+			return conn, nil
+		}
+		if err := p.discard(conn); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Takes an idle connection to any of the servers, the connection becomes busy
+func (p *Pool) takeAnyIdle(ctx context.Context, serverNames []string, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
 	if !p.serversMut.TryLock(ctx) {
 		return nil, racing.LockTimeoutError("could not acquire server lock in time when getting idle connection")
 	}
 	defer p.serversMut.Unlock()
 	for _, serverName := range serverNames {
 		srv := p.servers[serverName]
-		if srv != nil {
+		for srv != nil {
 			// Try to get an existing idle connection
-			conn, _ := srv.getIdle(ctx, idlenessThreshold)
+			conn, found := srv.getIdle(ctx, idlenessThreshold, auth)
+			if !found {
+				break
+			}
 			if conn != nil {
 				return conn, nil
 			}
@@ -208,7 +432,60 @@ func (p *Pool) tryAnyIdle(ctx context.Context, serverNames []string, idlenessThr
 	return nil, nil
 }
 
-func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error) {
+// Unregisters and closes a borrowed connection that could not be re-authenticated.
+// The caller's context may be done already, the connection is closed regardless.
+func (p *Pool) discard(conn db.Connection) error {
+	return p.unreg(context.Background(), conn.ServerName(), conn, p.now())
+}
+
+// Makes sure that the connection is authenticated with the provided token, re-authenticating it when needed.
+// Returns false when the connection could not be re-authenticated and should be discarded.
+func (p *Pool) reAuthenticate(ctx context.Context, conn db.Connection, auth *db.ReAuthToken) bool {
+	if auth == nil || conn.IsAuthenticatedWith(auth.Token) {
+		return true
+	}
+	if err := conn.ReAuth(ctx, auth); err != nil {
+		p.log.Debugf(log.Pool, p.logId, "Discarding connection to %s that could not be re-authenticated: %s", conn.ServerName(), err)
+		return false
+	}
+	return true
+}
+
+// Metrics returns a snapshot of the metrics of every server the pool has connected to, by server name
+func (p *Pool) Metrics() map[string]ServerMetrics {
+	return p.metrics.snapshot()
+}
+
+func (p *Pool) newServer(serverName string) *server {
+	srv := NewServer()
+	srv.name = serverName
+	srv.metrics = p.metrics
+	return srv
+}
+
+// Borrow acquires a connection to one of the provided servers.
+// The connection is authenticated with the provided token before being returned, idle connections are re-authenticated
+// when possible or replaced by new connections otherwise. When the token is nil, the driver-level token is used for
+// new connections and idle connections are returned regardless of their authentication.
+func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
+	start := p.now()
+	conn, err := p.borrow(ctx, serverNames, wait, boltLogger, idlenessThreshold, auth)
+	acquisitionTime := p.now().Sub(start)
+	if conn != nil {
+		p.metrics.update(conn.ServerName(), func(m *ServerMetrics) {
+			m.Acquired++
+			m.AcquisitionTime += acquisitionTime
+		})
+	}
+	if timeout, isTimeout := err.(*PoolTimeout); isTimeout && timeout.server != "" {
+		p.metrics.update(timeout.server, func(m *ServerMetrics) {
+			m.AcquisitionTimeouts++
+		})
+	}
+	return conn, err
+}
+
+func (p *Pool) borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
 	if p.closed {
 		return nil, &PoolClosed{}
 	}
@@ -219,21 +496,29 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	if err != nil {
 		return nil, err
 	}
+	// Let the strategy, if any, select among the servers in their original order
+	selected := ""
+	if p.selectServer != nil && len(penalties) > 0 {
+		selected = p.selectAmong(penalties)
+	}
 	// Sort server penalties by lowest penalty
 	sort.Slice(penalties, func(i, j int) bool {
 		return penalties[i].penalty < penalties[j].penalty
 	})
+	if selected != "" {
+		preferServer(penalties, selected)
+	}
 
 	var conn db.Connection
 	for _, s := range penalties {
-		conn, err = p.tryBorrow(ctx, s.name, boltLogger, idlenessThreshold)
+		conn, err = p.tryBorrow(ctx, s.name, boltLogger, idlenessThreshold, auth)
 		if err == nil {
 			return conn, nil
 		}
 
 		if bolt.IsTimeoutError(err) {
 			p.log.Warnf(log.Pool, p.logId, "Borrow time-out")
-			return nil, &PoolTimeout{servers: serverNames, err: err}
+			return nil, &PoolTimeout{servers: serverNames, err: err, server: s.name}
 		}
 	}
 
@@ -264,7 +549,7 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	// Ok, now that we own the queue we can add the item there but between getting the lock
 	// and above check for an existing connection another thread might have returned a connection
 	// so check again to avoid potentially starving this thread.
-	conn, err = p.tryAnyIdle(ctx, serverNames, idlenessThreshold)
+	conn, err = p.tryAnyIdle(ctx, serverNames, idlenessThreshold, auth)
 	if err != nil {
 		p.queueMut.Unlock()
 		return nil, err
@@ -286,7 +571,7 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 	// Wait for either a wake-up signal that indicates that we got a connection or a timeout.
 	select {
 	case <-q.wakeup:
-		return q.conn, nil
+		return p.reAuthenticateQueued(ctx, q.conn, boltLogger, idlenessThreshold, auth)
 	case <-ctx.Done():
 		// TODO: provided ctx has reached deadline already - set some hardcoded timeout instead?
 		if !p.queueMut.TryLock(context.Background()) {
@@ -295,60 +580,251 @@ func (p *Pool) Borrow(ctx context.Context, serverNames []string, wait bool, bolt
 		p.queue.Remove(e)
 		p.queueMut.Unlock()
 		if q.conn != nil {
-			return q.conn, nil
+			return p.reAuthenticateQueued(ctx, q.conn, boltLogger, idlenessThreshold, auth)
 		}
 		p.log.Warnf(log.Pool, p.logId, "Borrow time-out")
-		return nil, &PoolTimeout{err: ctx.Err(), servers: serverNames}
+		// Waiting was for any of the servers, the timeout is accounted to the preferred one
+		return nil, &PoolTimeout{err: ctx.Err(), servers: serverNames, server: penalties[0].name}
 	}
 }
 
-func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error) {
+// Connections handed over from another thread may be authenticated with another token, if such a connection
+// cannot be re-authenticated it is replaced by a new connection to the same server.
+func (p *Pool) reAuthenticateQueued(ctx context.Context, conn db.Connection, boltLogger log.BoltLogger, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
+	conn.SetBoltLogger(boltLogger)
+	if p.reAuthenticate(ctx, conn, auth) {
+		return conn, nil
+	}
+	if err := p.discard(conn); err != nil {
+		return nil, err
+	}
+	return p.tryBorrow(ctx, conn.ServerName(), boltLogger, idlenessThreshold, auth)
+}
+
+func (p *Pool) tryBorrow(ctx context.Context, serverName string, boltLogger log.BoltLogger, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
+	for {
+		conn, idle, err := p.takeIdleOrConnect(ctx, serverName, boltLogger, idlenessThreshold, auth)
+		if !idle || err != nil {
+			return conn, err
+		}
+		conn.SetBoltLogger(boltLogger)
+		// Re-authenticating requires a round trip to the server, the server lock is not held meanwhile
+		if p.reAuthenticate(ctx, conn, auth) {
+			return conn, nil
+		}
+		if err := p.discard(conn); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Takes an idle connection to the server or connects to it when there is none, the connection becomes busy.
+// Returns whether the connection was idle, in which case it may still need to be re-authenticated.
+func (p *Pool) takeIdleOrConnect(ctx context.Context, serverName string, boltLogger log.BoltLogger, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, bool, error) {
 	// For now, lock complete servers map to avoid over connecting but with the downside
 	// that long connect times will block connects to other servers as well. To fix this
 	// we would need to add a pending connect to the server and lock per server.
 	if !p.serversMut.TryLock(ctx) {
-		return nil, racing.LockTimeoutError("could not acquire lock in time when borrowing a connection")
+		return nil, false, racing.LockTimeoutError("could not acquire lock in time when borrowing a connection")
 	}
 	defer p.serversMut.Unlock()
 
 	srv := p.servers[serverName]
 	if srv != nil {
 		for {
-			connection, found := srv.getIdle(ctx, idlenessThreshold)
+			connection, found := srv.getIdle(ctx, idlenessThreshold, auth)
 			if connection == nil && found {
 				continue
 			}
 			if connection != nil {
-				connection.SetBoltLogger(boltLogger)
-				return connection, nil
+				return connection, true, nil
 			}
 			if srv.size() >= p.maxSize {
-				return nil, &PoolFull{servers: []string{serverName}}
+				return nil, false, &PoolFull{servers: []string{serverName}}
 			}
 			break
 		}
 	} else {
 		// Make sure that there is a server in the map
-		srv = NewServer()
+		srv = p.newServer(serverName)
 		p.servers[serverName] = srv
 	}
 
 	// No idle connection, try to connect
-	p.log.Infof(log.Pool, p.logId, "Connecting to %s", serverName)
-	c, err := p.connect(ctx, serverName, boltLogger)
+	c, err := p.connectTo(ctx, srv, auth, boltLogger)
 	if err != nil {
-		// Failed to connect, keep track that it was bad for a while
-		srv.notifyFailedConnect(p.now())
-		p.log.Warnf(log.Pool, p.logId, "Failed to connect to %s: %s", serverName, err)
-		return nil, err
+		return nil, false, err
 	}
 
 	// Ok, got a connection, register the connection
 	srv.registerBusy(c)
-	srv.notifySuccessfulConnect()
+	return c, false, nil
+}
+
+// Connects to the server, the caller is responsible for registering the connection and must hold the server lock
+func (p *Pool) connectTo(ctx context.Context, srv *server, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+	p.startConnect(srv)
+	c, err := p.connect(ctx, srv.name, auth, boltLogger)
+	if err := p.endConnect(srv, err); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
+// Reserves a connection slot on the server until endConnect is called, the caller must hold the server lock
+func (p *Pool) startConnect(srv *server) {
+	p.log.Infof(log.Pool, p.logId, "Connecting to %s", srv.name)
+	srv.connecting++
+	srv.updateMetrics(func(m *ServerMetrics) {
+		m.Creating++
+	})
+}
+
+// Releases the connection slot reserved by startConnect, the caller must hold the server lock
+func (p *Pool) endConnect(srv *server, err error) error {
+	srv.connecting--
+	srv.updateMetrics(func(m *ServerMetrics) {
+		m.Creating--
+		if err != nil {
+			m.FailedToCreate++
+		} else {
+			m.Created++
+		}
+	})
+	if err != nil {
+		// Failed to connect, keep track that it was bad for a while
+		srv.notifyFailedConnect(p.now())
+		p.log.Warnf(log.Pool, p.logId, "Failed to connect to %s: %s", srv.name, err)
+		return err
+	}
+	srv.notifySuccessfulConnect()
+	return nil
+}
+
+// WarmUp establishes idle connections to the provided servers ahead of their first use, so that borrowers do
+// not pay for connecting. The minimum number of idle connections, or at least one, is established per server
+// and these servers replace the ones the pool maintains the minimum of idle connections to.
+// Every server is warmed up even when connecting to some of them fails, the first error is returned.
+func (p *Pool) WarmUp(ctx context.Context, serverNames []string, auth *db.ReAuthToken) error {
+	if p.closed {
+		return &PoolClosed{}
+	}
+	if !p.serversMut.TryLock(ctx) {
+		return racing.LockTimeoutError("could not acquire server lock in time when warming up pool")
+	}
+	p.warmServers = append([]string(nil), serverNames...)
+	p.serversMut.Unlock()
+
+	minIdle := p.minIdle
+	if minIdle == 0 {
+		minIdle = 1
+	}
+	var firstErr error
+	for _, serverName := range serverNames {
+		if err := p.fillIdle(ctx, serverName, minIdle, auth); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Connects to the server until it has minIdle idle connections or the server is full
+func (p *Pool) fillIdle(ctx context.Context, serverName string, minIdle int, auth *db.ReAuthToken) error {
+	for {
+		select {
+		case <-p.stop:
+			return &PoolClosed{}
+		default:
+		}
+		filled, err := p.connectIdle(ctx, serverName, minIdle, auth)
+		if filled || err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Pool) connectIdle(ctx context.Context, serverName string, minIdle int, auth *db.ReAuthToken) (bool, error) {
+	if !p.serversMut.TryLock(ctx) {
+		return false, racing.LockTimeoutError("could not acquire server lock in time when connecting idle connection")
+	}
+	srv := p.servers[serverName]
+	if srv == nil {
+		srv = p.newServer(serverName)
+		p.servers[serverName] = srv
+	}
+	if srv.numIdle()+srv.connecting >= minIdle || srv.size() >= p.maxSize {
+		p.serversMut.Unlock()
+		return true, nil
+	}
+	// Nobody waits for idle connections, the server lock is not held while connecting
+	p.startConnect(srv)
+	p.serversMut.Unlock()
+	c, err := p.connect(ctx, serverName, auth, nil)
+
+	// The reserved slot must be released even when ctx is done
+	if !p.serversMut.TryLock(context.Background()) {
+		return false, racing.LockTimeoutError("could not acquire server lock in time when registering idle connection")
+	}
+	defer p.serversMut.Unlock()
+	if err := p.endConnect(srv, err); err != nil {
+		return false, err
+	}
+	if p.closed || p.servers[serverName] != srv {
+		go c.Close(context.Background())
+		return true, nil
+	}
+	srv.registerIdle(c, p.now())
+	return false, nil
+}
+
+// Signals the replenishing goroutine, if any, that connections have been evicted
+func (p *Pool) notifyEvicted() {
+	if p.minIdle == 0 {
+		return
+	}
+	select {
+	case p.replenish <- struct{}{}:
+	default:
+	}
+}
+
+// Replenishes the idle connections of the warmed up servers, until the pool is closed
+func (p *Pool) replenishIdle() {
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-p.replenish:
+		}
+		if !p.serversMut.TryLock(context.Background()) {
+			continue
+		}
+		serverNames := p.warmServers
+		p.serversMut.Unlock()
+		auth, err := p.replenishToken()
+		if err != nil {
+			p.log.Warnf(log.Pool, p.logId, "Failed to get token to replenish idle connections with: %s", err)
+			continue
+		}
+		for _, serverName := range serverNames {
+			ctx, cancel := context.WithTimeout(context.Background(), p.replenishTimeout)
+			if err := p.fillIdle(ctx, serverName, p.minIdle, auth); err != nil {
+				p.log.Warnf(log.Pool, p.logId, "Failed to replenish idle connections to %s: %s", serverName, err)
+			}
+			cancel()
+		}
+	}
+}
+
+func (p *Pool) replenishToken() (*db.ReAuthToken, error) {
+	if p.authToken == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), p.replenishTimeout)
+	defer cancel()
+	return p.authToken(ctx)
+}
+
 func (p *Pool) unreg(ctx context.Context, serverName string, c db.Connection, now time.Time) error {
 	if !p.serversMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire server lock in time when unregistering server")
@@ -368,6 +844,7 @@ func (p *Pool) unreg(ctx context.Context, serverName string, c db.Connection, no
 	}
 
 	server.unregisterBusy(c)
+	p.notifyEvicted()
 	if server.size() == 0 && !server.hasFailedConnect(now) {
 		delete(p.servers, serverName)
 	}
@@ -383,10 +860,24 @@ func (p *Pool) removeIdleOlderThanOnServer(ctx context.Context, serverName strin
 	if server == nil {
 		return nil
 	}
-	server.removeIdleOlderThan(ctx, now, maxAge)
+	if server.removeIdleOlderThan(ctx, now, maxAge) > 0 {
+		p.notifyEvicted()
+	}
 	return nil
 }
 
+// observeLatency is best-effort: a missed sample must not prevent the connection from being returned.
+func (p *Pool) observeLatency(ctx context.Context, serverName string, latency time.Duration) {
+	if !p.serversMut.TryLock(ctx) {
+		p.log.Warnf(log.Pool, p.logId, "Could not acquire server lock in time when observing latency of %s", serverName)
+		return
+	}
+	defer p.serversMut.Unlock()
+	if server := p.servers[serverName]; server != nil {
+		server.observeLatency(latency)
+	}
+}
+
 func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 	if p.closed {
 		p.log.Warnf(log.Pool, p.logId, "Trying to return connection to closed pool")
@@ -395,6 +886,12 @@ func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 
 	// Get the name of the server that the connection belongs to.
 	serverName := c.ServerName()
+	// Only the latency of the last query run during the borrow is sampled.
+	if reporter, ok := c.(db.LatencyReporter); ok {
+		if latency := reporter.TakeRunLatency(); latency > 0 {
+			p.observeLatency(ctx, serverName, latency)
+		}
+	}
 	isAlive := c.IsAlive()
 	p.log.Debugf(log.Pool, p.logId, "Returning connection to %s {alive:%t}", serverName, isAlive)
 
@@ -462,7 +959,7 @@ func (p *Pool) Return(ctx context.Context, c db.Connection) error {
 	defer p.serversMut.Unlock()
 	server := p.servers[serverName]
 	if server != nil { // Strange when server not found
-		server.returnBusy(c)
+		server.returnBusy(c, now)
 	} else {
 		p.log.Warnf(log.Pool, p.logId, "Server %s not found", serverName)
 	}
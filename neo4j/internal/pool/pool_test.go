@@ -24,6 +24,7 @@ import (
 	"errors"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"math/rand"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -39,12 +40,12 @@ func TestPoolBorrowReturn(outer *testing.T) {
 	maxAge := 1 * time.Second
 	birthdate := time.Now()
 
-	succeedingConnect := func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+	succeedingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
 		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
 	}
 
 	failingError := errors.New("whatever")
-	failingConnect := func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+	failingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
 		return nil, failingError
 	}
 
@@ -57,7 +58,7 @@ func TestPoolBorrowReturn(outer *testing.T) {
 			}
 		}()
 		serverNames := []string{"srv1"}
-		conn, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		conn, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, conn, err)
 		if err := p.Return(ctx, conn); err != nil {
 			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
@@ -86,14 +87,14 @@ func TestPoolBorrowReturn(outer *testing.T) {
 		wg.Add(1)
 
 		// First thread borrows
-		c1, err1 := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c1, err1 := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c1, err1)
 
 		// Second thread tries to borrow the only allowed connection on the same server
 		go func() {
 			// Will block here until first thread detects me in the queue and returns the
 			// connection which will unblock here.
-			c2, err2 := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+			c2, err2 := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 			assertConnection(t, c2, err2)
 			wg.Done()
 		}()
@@ -125,11 +126,11 @@ func TestPoolBorrowReturn(outer *testing.T) {
 		serverNames := []string{"srv1"}
 
 		// First thread borrows
-		c1, err1 := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c1, err1 := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c1, err1)
 
 		// Actually don't need a thread here since we shouldn't block
-		c2, err2 := p.Borrow(ctx, serverNames, false, nil, DefaultLivenessCheckThreshold)
+		c2, err2 := p.Borrow(ctx, serverNames, false, nil, DefaultLivenessCheckThreshold, nil)
 		assertNoConnection(t, c2, err2)
 		// Error should be pool full
 		_ = err2.(*PoolFull)
@@ -146,7 +147,7 @@ func TestPoolBorrowReturn(outer *testing.T) {
 
 		worker := func() {
 			for i := 0; i < 5; i++ {
-				c, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+				c, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 				assertConnection(t, c, err)
 				time.Sleep(time.Duration(rand.Int()%7) * time.Millisecond)
 				if err := p.Return(ctx, c); err != nil {
@@ -177,7 +178,7 @@ func TestPoolBorrowReturn(outer *testing.T) {
 		p := New(2, maxAge, failingConnect, logger, "pool id")
 		p.now = func() time.Time { return birthdate }
 		serverNames := []string{"srv1"}
-		c, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c, err := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertNoConnection(t, c, err)
 		// Should get the connect error back
 		if err != failingError {
@@ -188,13 +189,13 @@ func TestPoolBorrowReturn(outer *testing.T) {
 	outer.Run("Cancel Borrow", func(t *testing.T) {
 		p := New(1, maxAge, succeedingConnect, logger, "pool id")
 		p.now = func() time.Time { return birthdate }
-		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		cancelableCtx, cancel := context.WithCancel(ctx)
 		wg := sync.WaitGroup{}
 		var err error
 		wg.Add(1)
 		go func() {
-			_, err = p.Borrow(cancelableCtx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+			_, err = p.Borrow(cancelableCtx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
 			wg.Done()
 		}()
 
@@ -233,7 +234,7 @@ func TestPoolBorrowReturn(outer *testing.T) {
 			whatATimeToBeAlive,
 		}})
 
-		result, err := pool.tryBorrow(ctx, "a server", nil, idlenessThreshold)
+		result, err := pool.tryBorrow(ctx, "a server", nil, idlenessThreshold, nil)
 
 		testutil.AssertNil(t, err)
 		testutil.AssertDeepEquals(t, result, stayingAlive)
@@ -250,7 +251,7 @@ func TestPoolBorrowReturn(outer *testing.T) {
 		pool := New(1, maxAge, connectTo(healthyConnection), logger, "pool id")
 		setIdleConnections(pool, map[string][]db.Connection{"a server": {deadAfterReset1, deadAfterReset2}})
 
-		result, err := pool.tryBorrow(ctx, "a server", nil, idlenessThreshold)
+		result, err := pool.tryBorrow(ctx, "a server", nil, idlenessThreshold, nil)
 
 		testutil.AssertNil(t, err)
 		testutil.AssertDeepEquals(t, result, healthyConnection)
@@ -262,7 +263,7 @@ func TestPoolResourceUsage(ot *testing.T) {
 	maxAge := 1 * time.Second
 	birthdate := time.Now()
 
-	succeedingConnect := func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+	succeedingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
 		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
 	}
 
@@ -275,7 +276,7 @@ func TestPoolResourceUsage(ot *testing.T) {
 			}
 		}()
 		serverNames := []string{"srvA", "srvB", "srvC", "srvD"}
-		c, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		if c.ServerName() != serverNames[0] {
 			t.Errorf("Should have created server for first server but created for %s", c.ServerName())
 		}
@@ -290,7 +291,7 @@ func TestPoolResourceUsage(ot *testing.T) {
 			}
 		}()
 		serverNames := []string{"srvA"}
-		c, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		c.(*testutil.ConnFake).Alive = false
 		if err := p.Return(ctx, c); err != nil {
 			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
@@ -313,7 +314,7 @@ func TestPoolResourceUsage(ot *testing.T) {
 			}
 		}()
 		serverNames := []string{"srvA"}
-		c, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		if err := p.Return(ctx, c); err != nil {
 			t.Errorf("Should not fail returning connection to pool, but got: %v", err)
 		}
@@ -329,9 +330,9 @@ func TestPoolResourceUsage(ot *testing.T) {
 	ot.Run("Returning dead connection to server should remove older idle connections", func(t *testing.T) {
 		p := New(3, 0, succeedingConnect, logger, "pool id")
 		// Trigger creation of three connections on the same server
-		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
-		c2, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
-		c3, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		c2, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		c3, _ := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		// Manipulate birthdate on the connections
 		now := time.Now()
 		c1.(*testutil.ConnFake).Birth = now.Add(-1 * time.Second)
@@ -372,7 +373,7 @@ func TestPoolResourceUsage(ot *testing.T) {
 			}
 		}()
 		serverNames := []string{"srvA"}
-		c1, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c1, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		c1.(*testutil.ConnFake).Id = 123
 		// It's alive when returning it
 		if err := p.Return(ctx, c1); err != nil {
@@ -382,7 +383,7 @@ func TestPoolResourceUsage(ot *testing.T) {
 		now = now.Add(2 * maxAge)
 		nowMut.Unlock()
 		// Shouldn't get the same one back!
-		c2, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold)
+		c2, _ := p.Borrow(ctx, serverNames, true, nil, DefaultLivenessCheckThreshold, nil)
 		if c2.(*testutil.ConnFake).Id == 123 {
 			t.Errorf("Got the old connection back!")
 		}
@@ -396,26 +397,140 @@ func TestPoolResourceUsage(ot *testing.T) {
 				t.Errorf("Should not fail closing the pool, but got: %v", err)
 			}
 		}()
-		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c1, err)
-		c2, err := p.Borrow(ctx, []string{"B"}, true, nil, DefaultLivenessCheckThreshold)
+		c2, err := p.Borrow(ctx, []string{"B"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c2, err)
 		assertNumberOfServers(t, ctx, p, 2)
 	})
 }
 
+func TestPoolReAuthentication(outer *testing.T) {
+	driverToken := map[string]interface{}{"scheme": "basic", "principal": "driver"}
+	sessionToken := &db.ReAuthToken{Token: map[string]interface{}{"scheme": "basic", "principal": "session"}, FromSession: true}
+
+	outer.Run("connects with the requested token", func(t *testing.T) {
+		var connectToken *db.ReAuthToken
+		connect := func(_ context.Context, s string, auth *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+			connectToken = auth
+			return &testutil.ConnFake{Name: s, Alive: true, Birth: time.Now(), Auth: auth.Token}, nil
+		}
+		p := New(1, 0, connect, logger, "pool id")
+		defer p.Close(ctx)
+
+		conn, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, sessionToken)
+
+		assertConnection(t, conn, err)
+		testutil.AssertDeepEquals(t, connectToken, sessionToken)
+	})
+
+	outer.Run("re-authenticates idle connections", func(t *testing.T) {
+		conn := &testutil.ConnFake{Name: "A", Alive: true, Birth: time.Now(), Auth: driverToken}
+		p := New(1, 0, connectTo(conn), logger, "pool id")
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{"A": {conn}})
+
+		result, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, sessionToken)
+
+		assertConnection(t, result, err)
+		testutil.AssertDeepEquals(t, result, conn)
+		testutil.AssertTrue(t, conn.IsAuthenticatedWith(sessionToken.Token))
+	})
+
+	outer.Run("does not re-authenticate connections with the same token", func(t *testing.T) {
+		conn := &testutil.ConnFake{Name: "A", Alive: true, Birth: time.Now(), Auth: sessionToken.Token}
+		conn.ReAuthHook = func(*db.ReAuthToken) {
+			t.Error("should not re-authenticate")
+		}
+		p := New(1, 0, connectTo(conn), logger, "pool id")
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{"A": {conn}})
+
+		result, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, sessionToken)
+
+		assertConnection(t, result, err)
+	})
+
+	outer.Run("re-authenticates idle connections without holding the server lock", func(t *testing.T) {
+		conn := &testutil.ConnFake{Name: "A", Alive: true, Birth: time.Now(), Auth: driverToken}
+		p := New(1, 0, connectTo(conn), logger, "pool id")
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{"A": {conn}})
+		conn.ReAuthHook = func(*db.ReAuthToken) {
+			lockCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+			defer cancel()
+			servers, err := p.getServers(lockCtx)
+			testutil.AssertNoError(t, err)
+			testutil.AssertIntEqual(t, servers["A"].numBusy(), 1)
+		}
+
+		result, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, sessionToken)
+
+		assertConnection(t, result, err)
+		testutil.AssertTrue(t, conn.IsAuthenticatedWith(sessionToken.Token))
+	})
+
+	outer.Run("replaces idle connections that cannot be re-authenticated", func(t *testing.T) {
+		oldConn := &testutil.ConnFake{Name: "A", Alive: true, Birth: time.Now(), Auth: driverToken,
+			ReAuthErr: errors.New("re-authentication not supported")}
+		newConn := &testutil.ConnFake{Name: "A", Alive: true, Birth: time.Now(), Auth: sessionToken.Token}
+		p := New(1, 0, connectTo(newConn), logger, "pool id")
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{"A": {oldConn}})
+
+		result, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, sessionToken)
+
+		assertConnection(t, result, err)
+		testutil.AssertDeepEquals(t, result, newConn)
+		assertNumberOfIdle(t, ctx, p, "A", 0)
+		servers, _ := p.getServers(ctx)
+		testutil.AssertIntEqual(t, servers["A"].numBusy(), 1)
+	})
+
+	outer.Run("re-authenticates connections handed over to queued borrowers", func(t *testing.T) {
+		connect := func(_ context.Context, s string, auth *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+			return &testutil.ConnFake{Name: s, Alive: true, Birth: time.Now(), Auth: auth.Token}, nil
+		}
+		p := New(1, 0, connect, logger, "pool id")
+		defer p.Close(ctx)
+		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, &db.ReAuthToken{Token: driverToken})
+		assertConnection(t, c1, err)
+
+		wg := sync.WaitGroup{}
+		wg.Add(1)
+		var c2 db.Connection
+		go func() {
+			defer wg.Done()
+			c2, err = p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, sessionToken)
+		}()
+		// Wait until entered queue
+		for {
+			if size, err := p.queueSize(ctx); err != nil {
+				t.Errorf("should not fail computing queue size, got: %v", err)
+			} else if size > 0 {
+				break
+			}
+		}
+		testutil.AssertNoError(t, p.Return(ctx, c1))
+		wg.Wait()
+
+		assertConnection(t, c2, err)
+		testutil.AssertTrue(t, c2.IsAuthenticatedWith(sessionToken.Token))
+	})
+}
+
 func TestPoolCleanup(ot *testing.T) {
 	birthdate := time.Now()
 	maxLife := 1 * time.Second
-	succeedingConnect := func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+	succeedingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
 		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
 	}
 
 	// Borrows a connection in server A and another in server B
 	borrowConnections := func(t *testing.T, p *Pool) (db.Connection, db.Connection) {
-		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c1, err)
-		c2, err := p.Borrow(ctx, []string{"B"}, true, nil, DefaultLivenessCheckThreshold)
+		c2, err := p.Borrow(ctx, []string{"B"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c2, err)
 		return c1, c2
 	}
@@ -472,7 +587,7 @@ func TestPoolCleanup(ot *testing.T) {
 	})
 
 	ot.Run("Should not remove servers with only idle connections but with recent connect failures ", func(t *testing.T) {
-		failingConnect := func(_ context.Context, s string, _ log.BoltLogger) (db.Connection, error) {
+		failingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
 			return nil, errors.New("an error")
 		}
 		p := New(0, maxLife, failingConnect, logger, "pool id")
@@ -481,7 +596,7 @@ func TestPoolCleanup(ot *testing.T) {
 				t.Errorf("Should not fail closing the pool, but got: %v", err)
 			}
 		}()
-		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold)
+		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
 		assertNoConnection(t, c1, err)
 		assertNumberOfServers(t, ctx, p, 1)
 		assertNumberOfIdle(t, ctx, p, "A", 0)
@@ -505,8 +620,533 @@ func TestPoolCleanup(ot *testing.T) {
 	})
 }
 
-func connectTo(singleConnection *testutil.ConnFake) func(ctx context.Context, name string, _ log.BoltLogger) (db.Connection, error) {
-	return func(ctx context.Context, name string, _ log.BoltLogger) (db.Connection, error) {
+func TestPoolWarmUp(outer *testing.T) {
+	birthdate := time.Now()
+	maxAge := time.Minute
+	succeedingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
+	}
+
+	outer.Run("establishes one idle connection per server by default", func(t *testing.T) {
+		p := New(5, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+
+		err := p.WarmUp(ctx, []string{"A", "B"}, nil)
+
+		testutil.AssertNoError(t, err)
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+		assertNumberOfIdle(t, ctx, p, "B", 1)
+	})
+
+	outer.Run("establishes the minimum of idle connections without exceeding the pool size", func(t *testing.T) {
+		p := New(2, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		p.SetMinIdle(3, time.Minute)
+		defer p.Close(ctx)
+
+		err := p.WarmUp(ctx, []string{"A"}, nil)
+
+		testutil.AssertNoError(t, err)
+		assertNumberOfIdle(t, ctx, p, "A", 2)
+		testutil.AssertDeepEquals(t, p.Metrics()["A"], ServerMetrics{Idle: 2, Created: 2})
+	})
+
+	outer.Run("warms up remaining servers when connecting fails", func(t *testing.T) {
+		connectErr := errors.New("connect failed")
+		connect := func(ctx context.Context, s string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+			if s == "A" {
+				return nil, connectErr
+			}
+			return succeedingConnect(ctx, s, auth, boltLogger)
+		}
+		p := New(5, maxAge, connect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+
+		err := p.WarmUp(ctx, []string{"A", "B"}, nil)
+
+		testutil.AssertDeepEquals(t, err, connectErr)
+		assertNumberOfIdle(t, ctx, p, "B", 1)
+	})
+
+	outer.Run("does not hold up borrowers while connecting", func(t *testing.T) {
+		release := make(chan struct{})
+		connect := func(ctx context.Context, s string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+			if s == "A" {
+				<-release
+			}
+			return succeedingConnect(ctx, s, auth, boltLogger)
+		}
+		p := New(5, maxAge, connect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		warmedUp := make(chan error)
+		go func() {
+			warmedUp <- p.WarmUp(ctx, []string{"A"}, nil)
+		}()
+		for p.Metrics()["A"].Creating == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		borrowCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+		conn, err := p.Borrow(borrowCtx, []string{"B"}, true, nil, DefaultLivenessCheckThreshold, nil)
+
+		assertConnection(t, conn, err)
+		close(release)
+		testutil.AssertNoError(t, <-warmedUp)
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+	})
+
+	outer.Run("gives up replenishing idle connections after the timeout", func(t *testing.T) {
+		var mut sync.Mutex
+		connects := 0
+		connect := func(ctx context.Context, s string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+			mut.Lock()
+			connects++
+			replenishing := connects > 1
+			mut.Unlock()
+			if replenishing {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}
+			return succeedingConnect(ctx, s, auth, boltLogger)
+		}
+		p := New(5, maxAge, connect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		p.SetMinIdle(1, 10*time.Millisecond)
+		defer p.Close(ctx)
+		testutil.AssertNoError(t, p.WarmUp(ctx, []string{"A"}, nil))
+
+		if err := p.removeIdleOlderThanOnServer(ctx, "A", birthdate, 0); err != nil {
+			t.Fatalf("Should not fail removing idle connections, but got: %v", err)
+		}
+
+		for p.Metrics()["A"].FailedToCreate == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		testutil.AssertIntEqual(t, int(p.Metrics()["A"].Creating), 0)
+	})
+
+	outer.Run("replenishes evicted idle connections", func(t *testing.T) {
+		p := New(5, maxAge, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		p.SetMinIdle(2, time.Minute)
+		defer p.Close(ctx)
+		testutil.AssertNoError(t, p.WarmUp(ctx, []string{"A"}, nil))
+
+		// Evict the idle connections as too old
+		if err := p.removeIdleOlderThanOnServer(ctx, "A", birthdate, 0); err != nil {
+			t.Fatalf("Should not fail removing idle connections, but got: %v", err)
+		}
+
+		for p.Metrics()["A"].Created < 4 {
+			time.Sleep(time.Millisecond)
+		}
+		assertNumberOfIdle(t, ctx, p, "A", 2)
+	})
+
+	outer.Run("replenishes evicted idle connections with the supplied token", func(t *testing.T) {
+		var mut sync.Mutex
+		var connectTokens []string
+		connect := func(ctx context.Context, s string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+			mut.Lock()
+			defer mut.Unlock()
+			connectTokens = append(connectTokens, auth.Token["credentials"].(string))
+			return succeedingConnect(ctx, s, auth, boltLogger)
+		}
+		p := New(5, maxAge, connect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		p.SetMinIdle(1, time.Minute)
+		// Rotates the token on every call, as token managers may
+		supplied := 0
+		p.SetAuthTokenSupplier(func(context.Context) (*db.ReAuthToken, error) {
+			supplied++
+			return &db.ReAuthToken{Token: map[string]interface{}{"credentials": "token" + strconv.Itoa(supplied)}}, nil
+		})
+		defer p.Close(ctx)
+		warmUpToken := &db.ReAuthToken{Token: map[string]interface{}{"credentials": "token0"}}
+		testutil.AssertNoError(t, p.WarmUp(ctx, []string{"A"}, warmUpToken))
+
+		for i := 0; i < 2; i++ {
+			// Evict the idle connections as too old
+			if err := p.removeIdleOlderThanOnServer(ctx, "A", birthdate, 0); err != nil {
+				t.Fatalf("Should not fail removing idle connections, but got: %v", err)
+			}
+			for p.Metrics()["A"].Created < int64(i+2) {
+				time.Sleep(time.Millisecond)
+			}
+		}
+
+		mut.Lock()
+		defer mut.Unlock()
+		testutil.AssertDeepEquals(t, connectTokens, []string{"token0", "token1", "token2"})
+	})
+
+	outer.Run("fails on closed pool", func(t *testing.T) {
+		p := New(5, maxAge, succeedingConnect, logger, "pool id")
+		testutil.AssertNoError(t, p.Close(ctx))
+
+		err := p.WarmUp(ctx, []string{"A"}, nil)
+
+		testutil.AssertSameType(t, err, &PoolClosed{})
+	})
+}
+
+func TestPoolHousekeeping(outer *testing.T) {
+	now := time.Now()
+	idleConnection := func(name string) *testutil.ConnFake {
+		return &testutil.ConnFake{Name: name, Alive: true, Birth: now, Idle: now}
+	}
+	knownServers := func(servers ...string) func(context.Context) ([]string, error) {
+		return func(context.Context) ([]string, error) {
+			return servers, nil
+		}
+	}
+
+	outer.Run("closes connections exceeding their lifetime", func(t *testing.T) {
+		p := New(5, time.Hour, nil, logger, "pool id")
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{"A": {idleConnection("A")}})
+		p.now = func() time.Time { return now.Add(time.Hour) }
+
+		err := p.housekeep(ctx, 2*time.Hour, nil)
+
+		testutil.AssertNoError(t, err)
+		assertNumberOfServers(t, ctx, p, 0)
+	})
+
+	outer.Run("closes connections exceeding their idle time", func(t *testing.T) {
+		p := New(5, time.Hour, nil, logger, "pool id")
+		p.SetMaxIdleTime(time.Minute)
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{"A": {idleConnection("A")}})
+		p.now = func() time.Time { return now.Add(2 * time.Minute) }
+
+		err := p.housekeep(ctx, time.Hour, nil)
+
+		testutil.AssertNoError(t, err)
+		assertNumberOfServers(t, ctx, p, 0)
+	})
+
+	outer.Run("closes connections exceeding their idle time on the pool clock", func(t *testing.T) {
+		returnedAt := now.Add(-time.Hour)
+		conn := idleConnection("A")
+		p := New(5, 2*time.Hour, connectTo(conn), logger, "pool id")
+		p.SetMaxIdleTime(time.Minute)
+		defer p.Close(ctx)
+		p.now = func() time.Time { return returnedAt }
+		borrowed, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, borrowed, err)
+		testutil.AssertNoError(t, p.Return(ctx, borrowed))
+
+		p.now = func() time.Time { return returnedAt.Add(30 * time.Second) }
+		testutil.AssertNoError(t, p.housekeep(ctx, time.Hour, nil))
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+		p.now = func() time.Time { return returnedAt.Add(2 * time.Minute) }
+		testutil.AssertNoError(t, p.housekeep(ctx, time.Hour, nil))
+		assertNumberOfServers(t, ctx, p, 0)
+	})
+
+	outer.Run("pings quiet idle connections and closes dead ones", func(t *testing.T) {
+		p := New(5, time.Hour, nil, logger, "pool id")
+		defer p.Close(ctx)
+		alive := idleConnection("A")
+		alive.Idle = now.Add(-time.Hour)
+		alive.ForceResetHook = func() {
+			alive.Idle = now
+		}
+		dead := deadConnectionAfterForceReset("A", now.Add(-time.Hour))
+		dead.Birth = now
+		recent := idleConnection("A")
+		setIdleConnections(p, map[string][]db.Connection{"A": {alive, dead, recent}})
+		p.now = func() time.Time { return now }
+
+		err := p.housekeep(ctx, time.Minute, nil)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertTrue(t, alive.IsAlive())
+		testutil.AssertFalse(t, dead.IsAlive())
+		assertNumberOfIdle(t, ctx, p, "A", 2)
+	})
+
+	outer.Run("closes idle connections to servers no longer part of any routing table", func(t *testing.T) {
+		p := New(5, time.Hour, nil, logger, "pool id")
+		defer p.Close(ctx)
+		setIdleConnections(p, map[string][]db.Connection{
+			"A": {idleConnection("A")},
+			"B": {idleConnection("B")},
+			"C": {idleConnection("C")},
+		})
+		p.warmServers = []string{"C"}
+		p.now = func() time.Time { return now }
+
+		err := p.housekeep(ctx, time.Hour, knownServers("A"))
+
+		testutil.AssertNoError(t, err)
+		assertNumberOfServers(t, ctx, p, 2)
+		assertNumberOfIdle(t, ctx, p, "A", 1)
+		assertNumberOfIdle(t, ctx, p, "C", 1)
+	})
+
+	outer.Run("runs in the background until the pool is closed", func(t *testing.T) {
+		p := New(5, time.Hour, nil, logger, "pool id")
+		p.SetMaxIdleTime(time.Millisecond)
+		setIdleConnections(p, map[string][]db.Connection{"A": {idleConnection("A")}})
+
+		p.StartHousekeeping(time.Millisecond, nil)
+
+		for {
+			servers, err := p.getServers(ctx)
+			testutil.AssertNoError(t, err)
+			if len(servers) == 0 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+		testutil.AssertNoError(t, p.Close(ctx))
+	})
+}
+
+func TestPoolServerSelection(outer *testing.T) {
+	birthdate := time.Now()
+	succeedingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
+	}
+
+	outer.Run("borrows from the selected server", func(t *testing.T) {
+		p := New(5, time.Hour, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		c, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		var candidates []ServerStats
+		p.SetSelectServer(func(servers []ServerStats) string {
+			candidates = servers
+			return "A"
+		})
+
+		c, err = p.Borrow(ctx, []string{"A", "B"}, true, nil, DefaultLivenessCheckThreshold, nil)
+
+		assertConnection(t, c, err)
+		testutil.AssertStringEqual(t, c.ServerName(), "A")
+		testutil.AssertDeepEquals(t, candidates, []ServerStats{{Address: "A", InUse: 1}, {Address: "B"}})
+	})
+
+	outer.Run("falls back to other servers when connecting to the selected server fails", func(t *testing.T) {
+		connect := func(ctx context.Context, s string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (db.Connection, error) {
+			if s == "A" {
+				return nil, errors.New("connect failed")
+			}
+			return succeedingConnect(ctx, s, auth, boltLogger)
+		}
+		p := New(5, time.Hour, connect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		p.SetSelectServer(func([]ServerStats) string {
+			return "A"
+		})
+
+		c, err := p.Borrow(ctx, []string{"A", "B"}, true, nil, DefaultLivenessCheckThreshold, nil)
+
+		assertConnection(t, c, err)
+		testutil.AssertStringEqual(t, c.ServerName(), "B")
+	})
+
+	outer.Run("reports the latency of returned connections", func(t *testing.T) {
+		p := New(5, time.Hour, succeedingConnect, logger, "pool id")
+		p.now = func() time.Time { return birthdate }
+		defer p.Close(ctx)
+		var candidates []ServerStats
+		p.SetSelectServer(func(servers []ServerStats) string {
+			candidates = servers
+			return servers[0].Address
+		})
+		c, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		c.(*testutil.ConnFake).RunLatency = 5 * time.Millisecond
+		testutil.AssertNoError(t, p.Return(ctx, c))
+
+		_, err = p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, candidates, []ServerStats{{Address: "A", Idle: 1, Latency: 5 * time.Millisecond}})
+	})
+}
+
+func TestPoolMetrics(outer *testing.T) {
+	maxAge := 1 * time.Hour
+	birthdate := time.Now()
+
+	succeedingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+		return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
+	}
+
+	fixedClock := func() time.Time {
+		return birthdate
+	}
+
+	outer.Run("tracks borrowed and returned connections", func(t *testing.T) {
+		p := New(2, maxAge, succeedingConnect, logger, "pool id")
+		p.now = fixedClock
+		defer p.Close(ctx)
+
+		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c1, err)
+		c2, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c2, err)
+		testutil.AssertDeepEquals(t, p.Metrics()["A"], ServerMetrics{
+			InUse:    2,
+			Created:  2,
+			Acquired: 2,
+		})
+
+		testutil.AssertNoError(t, p.Return(ctx, c1))
+		metrics := p.Metrics()["A"]
+		testutil.AssertIntEqual(t, int(metrics.Idle), 1)
+		testutil.AssertIntEqual(t, int(metrics.InUse), 1)
+		testutil.AssertIntEqual(t, int(metrics.Closed), 0)
+	})
+
+	outer.Run("tracks idle connections reused by borrowers", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = fixedClock
+		defer p.Close(ctx)
+
+		c, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		testutil.AssertNoError(t, p.Return(ctx, c))
+		c, err = p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+
+		metrics := p.Metrics()["A"]
+		testutil.AssertIntEqual(t, int(metrics.Idle), 0)
+		testutil.AssertIntEqual(t, int(metrics.InUse), 1)
+		testutil.AssertIntEqual(t, int(metrics.Created), 1)
+		testutil.AssertIntEqual(t, int(metrics.Acquired), 2)
+	})
+
+	outer.Run("tracks closed connections", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = fixedClock
+
+		c, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		c.(*testutil.ConnFake).Alive = false
+		testutil.AssertNoError(t, p.Return(ctx, c))
+		c, err = p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		testutil.AssertNoError(t, p.Return(ctx, c))
+		testutil.AssertNoError(t, p.Close(ctx))
+
+		testutil.AssertDeepEquals(t, p.Metrics()["A"], ServerMetrics{
+			Created:  2,
+			Closed:   2,
+			Acquired: 2,
+		})
+	})
+
+	outer.Run("tracks connections failing the liveness check", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = fixedClock
+		defer p.Close(ctx)
+
+		c, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		dead := c.(*testutil.ConnFake)
+		dead.ForceResetHook = func() {
+			dead.Alive = false
+		}
+		testutil.AssertNoError(t, p.Return(ctx, c))
+		c, err = p.Borrow(ctx, []string{"A"}, true, nil, 0, nil)
+		assertConnection(t, c, err)
+
+		testutil.AssertTrue(t, c.(*testutil.ConnFake) != dead)
+		testutil.AssertDeepEquals(t, p.Metrics()["A"], ServerMetrics{
+			InUse:                1,
+			Created:              2,
+			Closed:               1,
+			Acquired:             2,
+			FailedLivenessChecks: 1,
+		})
+	})
+
+	outer.Run("tracks acquisition time", func(t *testing.T) {
+		now := birthdate
+		slowConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+			now = now.Add(5 * time.Second)
+			return &testutil.ConnFake{Name: s, Alive: true, Birth: birthdate}, nil
+		}
+		p := New(2, maxAge, slowConnect, logger, "pool id")
+		p.now = func() time.Time { return now }
+		defer p.Close(ctx)
+
+		c1, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c1, err)
+		c2, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c2, err)
+
+		metrics := p.Metrics()["A"]
+		testutil.AssertIntEqual(t, int(metrics.Acquired), 2)
+		testutil.AssertDeepEquals(t, metrics.AcquisitionTime, 10*time.Second)
+	})
+
+	outer.Run("tracks failed connection attempts", func(t *testing.T) {
+		failingConnect := func(_ context.Context, s string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+			return nil, errors.New("whatever")
+		}
+		p := New(1, maxAge, failingConnect, logger, "pool id")
+		p.now = fixedClock
+		defer p.Close(ctx)
+
+		_, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		testutil.AssertError(t, err)
+
+		testutil.AssertDeepEquals(t, p.Metrics()["A"], ServerMetrics{FailedToCreate: 1})
+	})
+
+	outer.Run("tracks acquisition timeouts", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = fixedClock
+		defer p.Close(ctx)
+		c, err := p.Borrow(ctx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+		assertConnection(t, c, err)
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err = p.Borrow(timeoutCtx, []string{"A"}, true, nil, DefaultLivenessCheckThreshold, nil)
+
+		testutil.AssertSameType(t, err, &PoolTimeout{})
+		metrics := p.Metrics()["A"]
+		testutil.AssertIntEqual(t, int(metrics.AcquisitionTimeouts), 1)
+		testutil.AssertIntEqual(t, int(metrics.Acquired), 1)
+	})
+
+	outer.Run("tracks acquisition timeouts among several servers once", func(t *testing.T) {
+		p := New(1, maxAge, succeedingConnect, logger, "pool id")
+		p.now = fixedClock
+		defer p.Close(ctx)
+		for _, serverName := range []string{"A", "B", "C"} {
+			c, err := p.Borrow(ctx, []string{serverName}, true, nil, DefaultLivenessCheckThreshold, nil)
+			assertConnection(t, c, err)
+		}
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := p.Borrow(timeoutCtx, []string{"A", "B", "C"}, true, nil, DefaultLivenessCheckThreshold, nil)
+
+		testutil.AssertSameType(t, err, &PoolTimeout{})
+		timeouts := 0
+		for _, metrics := range p.Metrics() {
+			timeouts += int(metrics.AcquisitionTimeouts)
+		}
+		testutil.AssertIntEqual(t, timeouts, 1)
+	})
+}
+
+func connectTo(singleConnection *testutil.ConnFake) func(ctx context.Context, name string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
+	return func(ctx context.Context, name string, _ *db.ReAuthToken, _ log.BoltLogger) (db.Connection, error) {
 		return singleConnection, nil
 	}
 }
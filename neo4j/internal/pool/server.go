@@ -35,12 +35,24 @@ type server struct {
 	busy            list.List
 	failedConnectAt time.Time
 	roundRobin      uint32
+	name            string
+	metrics         *metrics
+	// When idle connections were last returned, unlike their idle date it is not updated by pings
+	idleSince map[db.Connection]time.Time
+	// Idle connections taken out of the idle list to be pinged, they are neither idle nor busy meanwhile
+	pinging map[db.Connection]struct{}
+	// Number of connections being established, they count towards the size of the server
+	connecting int
+	// Exponentially weighted moving average of the query response times, zero until measured
+	latency time.Duration
 }
 
 func NewServer() *server {
 	return &server{
-		idle: list.List{},
-		busy: list.List{},
+		idle:      list.List{},
+		busy:      list.List{},
+		idleSince: make(map[db.Connection]time.Time),
+		pinging:   make(map[db.Connection]struct{}),
 	}
 }
 
@@ -48,20 +60,38 @@ var sharedRoundRobin uint32
 
 const rememberFailedConnectDuration = 3 * time.Minute
 
-// Returns an idle connection if any
-func (s *server) getIdle(ctx context.Context, idlenessThreshold time.Duration) (db.Connection, bool) {
+// Returns an idle connection if any, connections already authenticated with the provided token are preferred
+func (s *server) getIdle(ctx context.Context, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, bool) {
 	availableConnection := s.idle.Front()
 	found := availableConnection != nil
+	if found && auth != nil {
+		for e := availableConnection; e != nil; e = e.Next() {
+			if e.Value.(db.Connection).IsAuthenticatedWith(auth.Token) {
+				availableConnection = e
+				break
+			}
+		}
+	}
 	if found {
 		idleConnection := s.idle.Remove(availableConnection)
 		connection := idleConnection.(db.Connection)
+		delete(s.idleSince, connection)
 		if time.Now().Sub(connection.IdleDate()) > idlenessThreshold {
 			connection.ForceReset(ctx)
 			if !connection.IsAlive() {
+				s.updateMetrics(func(m *ServerMetrics) {
+					m.Idle--
+					m.Closed++
+					m.FailedLivenessChecks++
+				})
 				return nil, found
 			}
 		}
 		s.busy.PushFront(idleConnection)
+		s.updateMetrics(func(m *ServerMetrics) {
+			m.Idle--
+			m.InUse++
+		})
 		// Update round-robin counter every time we give away a connection and keep track
 		// of our own round-robin index
 		s.roundRobin = atomic.AddUint32(&sharedRoundRobin, 1)
@@ -70,6 +100,12 @@ func (s *server) getIdle(ctx context.Context, idlenessThreshold time.Duration) (
 	return nil, found
 }
 
+func (s *server) updateMetrics(update func(*ServerMetrics)) {
+	if s.metrics != nil {
+		s.metrics.update(s.name, update)
+	}
+}
+
 func (s *server) notifyFailedConnect(now time.Time) {
 	s.failedConnectAt = now
 }
@@ -85,6 +121,28 @@ func (s *server) hasFailedConnect(now time.Time) bool {
 	return now.Sub(s.failedConnectAt) < rememberFailedConnectDuration
 }
 
+// Weight of the latest response time in the moving average of the server latency.
+// The pool samples at most one response time per borrowed connection.
+const latencyWeight = 0.2
+
+func (s *server) observeLatency(latency time.Duration) {
+	if s.latency == 0 {
+		s.latency = latency
+		return
+	}
+	s.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(s.latency))
+}
+
+func (s *server) stats(now time.Time) ServerStats {
+	return ServerStats{
+		Address:        s.name,
+		InUse:          s.busy.Len(),
+		Idle:           s.idle.Len(),
+		Latency:        s.latency,
+		RecentlyFailed: s.hasFailedConnect(now),
+	}
+}
+
 const newConnectionPenalty = uint32(1 << 8)
 
 // Calculates a penalty value for how this server compares to other servers
@@ -115,10 +173,16 @@ func (s *server) calculatePenalty(now time.Time) uint32 {
 	return penalty
 }
 
-// Returns a busy connection, makes it idle
-func (s *server) returnBusy(c db.Connection) {
-	s.unregisterBusy(c)
+// Returns a busy connection, makes it idle since now
+func (s *server) returnBusy(c db.Connection, now time.Time) {
+	if s.removeBusy(c) {
+		s.updateMetrics(func(m *ServerMetrics) {
+			m.InUse--
+			m.Idle++
+		})
+	}
 	s.idle.PushFront(c)
+	s.idleSince[c] = now
 }
 
 // Number of idle connections
@@ -136,25 +200,48 @@ func (s *server) registerBusy(c db.Connection) {
 	// Update round-robin to indicate when this server was last used.
 	s.roundRobin = atomic.AddUint32(&sharedRoundRobin, 1)
 	s.busy.PushFront(c)
+	s.updateMetrics(func(m *ServerMetrics) {
+		m.InUse++
+	})
+}
+
+// Adds a new connection to idle list, idle since now
+func (s *server) registerIdle(c db.Connection, now time.Time) {
+	s.idle.PushFront(c)
+	s.idleSince[c] = now
+	s.updateMetrics(func(m *ServerMetrics) {
+		m.Idle++
+	})
 }
 
+// Removes a busy connection that is about to be closed
 func (s *server) unregisterBusy(c db.Connection) {
-	found := false
-	for e := s.busy.Front(); e != nil && !found; e = e.Next() {
+	if s.removeBusy(c) {
+		s.updateMetrics(func(m *ServerMetrics) {
+			m.InUse--
+			m.Closed++
+		})
+	}
+}
+
+func (s *server) removeBusy(c db.Connection) bool {
+	for e := s.busy.Front(); e != nil; e = e.Next() {
 		x := e.Value.(db.Connection)
-		found = x == c
-		if found {
+		if x == c {
 			s.busy.Remove(e)
-			return
+			return true
 		}
 	}
+	return false
 }
 
 func (s *server) size() int {
-	return s.busy.Len() + s.idle.Len()
+	return s.busy.Len() + s.idle.Len() + len(s.pinging) + s.connecting
 }
 
-func (s *server) removeIdleOlderThan(ctx context.Context, now time.Time, maxAge time.Duration) {
+// Removes idle connections older than maxAge and returns how many were removed
+func (s *server) removeIdleOlderThan(ctx context.Context, now time.Time, maxAge time.Duration) int {
+	removed := 0
 	e := s.idle.Front()
 	for e != nil {
 		n := e.Next()
@@ -162,16 +249,97 @@ func (s *server) removeIdleOlderThan(ctx context.Context, now time.Time, maxAge
 
 		age := now.Sub(c.Birthdate())
 		if age >= maxAge {
-			s.idle.Remove(e)
-			go c.Close(ctx)
+			s.removeIdle(ctx, e)
+			removed++
+		}
+
+		e = n
+	}
+	return removed
+}
+
+// Removes idle connections that have not been used for maxIdleTime and returns how many were removed
+func (s *server) removeIdleLongerThan(ctx context.Context, now time.Time, maxIdleTime time.Duration) int {
+	removed := 0
+	e := s.idle.Front()
+	for e != nil {
+		n := e.Next()
+		if now.Sub(s.idleSince[e.Value.(db.Connection)]) >= maxIdleTime {
+			s.removeIdle(ctx, e)
+			removed++
 		}
+		e = n
+	}
+	return removed
+}
+
+// Removes all idle connections and returns how many were removed
+func (s *server) removeAllIdle(ctx context.Context) int {
+	removed := s.idle.Len()
+	for e := s.idle.Front(); e != nil; e = s.idle.Front() {
+		s.removeIdle(ctx, e)
+	}
+	return removed
+}
+
+func (s *server) removeIdle(ctx context.Context, e *list.Element) {
+	c := s.idle.Remove(e).(db.Connection)
+	delete(s.idleSince, c)
+	s.updateMetrics(func(m *ServerMetrics) {
+		m.Idle--
+		m.Closed++
+	})
+	go c.Close(ctx)
+}
 
+// Takes the idle connections without traffic since idleDate out of the idle list, so that they can be pinged
+// without being borrowed meanwhile. They do not count as busy connections, they still count as idle connections
+// in the metrics of the server until they are handed back with returnPinged.
+func (s *server) takeIdleToPing(idleDate time.Time) []db.Connection {
+	var connections []db.Connection
+	e := s.idle.Front()
+	for e != nil {
+		n := e.Next()
+		c := e.Value.(db.Connection)
+		if !c.IdleDate().After(idleDate) {
+			s.idle.Remove(e)
+			s.pinging[c] = struct{}{}
+			connections = append(connections, c)
+		}
 		e = n
 	}
+	return connections
+}
+
+// Hands back a pinged connection, it becomes idle again when still alive and is removed otherwise
+func (s *server) returnPinged(c db.Connection) bool {
+	if _, pinging := s.pinging[c]; !pinging {
+		return false
+	}
+	delete(s.pinging, c)
+	if !c.IsAlive() {
+		delete(s.idleSince, c)
+		s.updateMetrics(func(m *ServerMetrics) {
+			m.Idle--
+			m.Closed++
+		})
+		return false
+	}
+	s.idle.PushBack(c)
+	return true
 }
 
 func (s *server) closeAll(ctx context.Context) {
+	numIdle, numBusy := int64(s.idle.Len()+len(s.pinging)), int64(s.busy.Len())
+	s.updateMetrics(func(m *ServerMetrics) {
+		m.Idle -= numIdle
+		m.InUse -= numBusy
+		m.Closed += numIdle + numBusy
+	})
 	closeAndEmptyConnections(ctx, s.idle)
+	s.idleSince = make(map[db.Connection]time.Time)
+	// Pinged connections are closed once handed back, see returnPinged
+	s.pinging = make(map[db.Connection]struct{})
 	// Closing the busy connections could mean here that we do close from another thread.
 	closeAndEmptyConnections(ctx, s.busy)
 }
@@ -76,16 +76,29 @@ func TestServer(ot *testing.T) {
 		c1 := &testutil.ConnFake{}
 		registerIdle(s, c1)
 
-		c2, _ := s.getIdle(context.Background(), DefaultLivenessCheckThreshold)
+		c2, _ := s.getIdle(context.Background(), DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c2)
-		c3, _ := s.getIdle(context.Background(), DefaultLivenessCheckThreshold)
+		c3, _ := s.getIdle(context.Background(), DefaultLivenessCheckThreshold, nil)
 		assertNilConnection(t, c3)
 
-		s.returnBusy(c2)
-		c3, _ = s.getIdle(context.Background(), DefaultLivenessCheckThreshold)
+		s.returnBusy(c2, time.Now())
+		c3, _ = s.getIdle(context.Background(), DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, c3)
 	})
 
+	ot.Run("getIdle prefers connections authenticated with the requested token", func(t *testing.T) {
+		s := NewServer()
+		token := map[string]interface{}{"scheme": "basic", "principal": "a"}
+		c1 := &testutil.ConnFake{Auth: map[string]interface{}{"scheme": "none"}}
+		c2 := &testutil.ConnFake{Auth: token}
+		registerIdle(s, c2)
+		registerIdle(s, c1)
+
+		c3, _ := s.getIdle(context.Background(), DefaultLivenessCheckThreshold, &db.ReAuthToken{Token: token})
+
+		testutil.AssertDeepEquals(t, c3, c2)
+	})
+
 	ot.Run("removeIdleOlderThan", func(t *testing.T) {
 		s := NewServer()
 		// Register and return three connections
@@ -104,22 +117,22 @@ func TestServer(ot *testing.T) {
 
 		ctx := context.Background()
 		// Should be able to borrow twice
-		b1, _ := s.getIdle(ctx, DefaultLivenessCheckThreshold)
+		b1, _ := s.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, b1)
-		b2, _ := s.getIdle(ctx, DefaultLivenessCheckThreshold)
+		b2, _ := s.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 		assertConnection(t, b2)
-		b3, _ := s.getIdle(ctx, DefaultLivenessCheckThreshold)
+		b3, _ := s.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 		assertNilConnection(t, b3)
 
 		// Return the connections and let all of them be too old
-		s.returnBusy(b1)
-		s.returnBusy(b2)
+		s.returnBusy(b1, time.Now())
+		s.returnBusy(b2, time.Now())
 		conns[0].Birth = now.Add(-20 * time.Second)
 		conns[2].Birth = now.Add(-20 * time.Second)
 		s.removeIdleOlderThan(context.Background(), now, 10*time.Second)
 
 		// Shouldn't be able to borrow anything and size should be zero
-		b1, _ = s.getIdle(ctx, DefaultLivenessCheckThreshold)
+		b1, _ = s.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 		assertNilConnection(t, b1)
 		assertSize(t, s, 0)
 	})
@@ -148,7 +161,7 @@ func TestServerPenalty(t *testing.T) {
 	// Return the busy connection to srv1
 	// Now srv2 should have higher penalty than srv1 since using srv2 would require a new
 	// connection.
-	srv1.returnBusy(c11)
+	srv1.returnBusy(c11, time.Now())
 	assertPenaltiesGreaterThan(srv2, srv1, now)
 
 	// Add an idle connection to srv2 to make both servers have one idle connection each.
@@ -161,9 +174,9 @@ func TestServerPenalty(t *testing.T) {
 
 	// Get the connection from srv1 and return it, now srv1 should have higher penalty.
 	ctx := context.Background()
-	idle, _ := srv1.getIdle(ctx, DefaultLivenessCheckThreshold)
+	idle, _ := srv1.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 	testutil.AssertDeepEquals(t, idle, c11)
-	srv1.returnBusy(c11)
+	srv1.returnBusy(c11, time.Now())
 	assertPenaltiesGreaterThan(srv1, srv2, now)
 
 	// Add one more connection each to the servers
@@ -175,19 +188,19 @@ func TestServerPenalty(t *testing.T) {
 	// Both servers have two idle connections, srv2 was last used, so it should have higher penalty.
 	assertPenaltiesGreaterThan(srv2, srv1, now)
 	// Get both idle connections from srv1
-	srv1.getIdle(ctx, DefaultLivenessCheckThreshold)
-	srv1.getIdle(ctx, DefaultLivenessCheckThreshold)
+	srv1.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
+	srv1.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 	// Get one idle connection from srv2
-	srv2.getIdle(ctx, DefaultLivenessCheckThreshold)
+	srv2.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 	// Since more connections are in use on srv1, it should have higher penalty even though
 	// srv2 was last used
 	assertPenaltiesGreaterThan(srv1, srv2, now)
 	// Return the connections
-	srv2.getIdle(ctx, DefaultLivenessCheckThreshold)
-	srv2.returnBusy(c21)
-	srv2.returnBusy(c22)
-	srv1.returnBusy(c11)
-	srv1.returnBusy(c12)
+	srv2.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
+	srv2.returnBusy(c21, time.Now())
+	srv2.returnBusy(c22, time.Now())
+	srv1.returnBusy(c11, time.Now())
+	srv1.returnBusy(c12, time.Now())
 	// Everything returned, srv2 should have higher penalty since it was last used
 	assertPenaltiesGreaterThan(srv2, srv1, now)
 
@@ -198,8 +211,8 @@ func TestServerPenalty(t *testing.T) {
 	testutil.AssertTrue(t, srv1.hasFailedConnect(now))
 	testutil.AssertFalse(t, srv2.hasFailedConnect(now))
 	// Use srv2 to the max
-	srv2.getIdle(ctx, DefaultLivenessCheckThreshold)
-	srv2.getIdle(ctx, DefaultLivenessCheckThreshold)
+	srv2.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
+	srv2.getIdle(ctx, DefaultLivenessCheckThreshold, nil)
 	// Even at this point we should prefer srv2
 	assertPenaltiesGreaterThan(srv1, srv2, now)
 
@@ -225,7 +238,7 @@ func TestIdlenessThreshold(outer *testing.T) {
 		srv := NewServer()
 		registerIdle(srv, connection)
 
-		idleConnection, found := srv.getIdle(context.Background(), math.MaxInt64)
+		idleConnection, found := srv.getIdle(context.Background(), math.MaxInt64, nil)
 
 		testutil.AssertTrue(t, found)
 		testutil.AssertFalse(t, resetCalled)
@@ -247,7 +260,7 @@ func TestIdlenessThreshold(outer *testing.T) {
 		srv := NewServer()
 		registerIdle(srv, connection)
 
-		idleConnection, found := srv.getIdle(context.Background(), 1*time.Hour)
+		idleConnection, found := srv.getIdle(context.Background(), 1*time.Hour, nil)
 
 		testutil.AssertTrue(t, found)
 		testutil.AssertTrue(t, resetCalled)
@@ -268,7 +281,7 @@ func TestIdlenessThreshold(outer *testing.T) {
 		srv := NewServer()
 		registerIdle(srv, connection)
 
-		idleConnection, found := srv.getIdle(context.Background(), 1*time.Hour)
+		idleConnection, found := srv.getIdle(context.Background(), 1*time.Hour, nil)
 
 		testutil.AssertTrue(t, found)
 		testutil.AssertNil(t, idleConnection)
@@ -277,9 +290,59 @@ func TestIdlenessThreshold(outer *testing.T) {
 		testutil.AssertIntEqual(t, srv.numIdle(), 0)
 		testutil.AssertIntEqual(t, srv.numBusy(), 0)
 	})
+
+	outer.Run("averages observed latencies", func(t *testing.T) {
+		srv := NewServer()
+
+		srv.observeLatency(10 * time.Millisecond)
+		testutil.AssertDeepEquals(t, srv.latency, 10*time.Millisecond)
+		srv.observeLatency(20 * time.Millisecond)
+		testutil.AssertDeepEquals(t, srv.latency, 12*time.Millisecond)
+	})
+
+	outer.Run("removes connections idle for too long", func(t *testing.T) {
+		srv := NewServer()
+		registerIdle(srv, &testutil.ConnFake{Alive: true})
+		now := time.Now()
+
+		testutil.AssertIntEqual(t, srv.removeIdleLongerThan(context.Background(), now, time.Hour), 0)
+		testutil.AssertIntEqual(t, srv.removeIdleLongerThan(context.Background(), now.Add(time.Hour), time.Hour), 1)
+		testutil.AssertIntEqual(t, srv.size(), 0)
+	})
+
+	outer.Run("keeps connections taken to be pinged out of reach of borrowers", func(t *testing.T) {
+		srv := NewServer()
+		now := time.Now()
+		recent := &testutil.ConnFake{Alive: true, Idle: now}
+		quiet := &testutil.ConnFake{Alive: true, Idle: now.Add(-time.Hour)}
+		registerIdle(srv, recent)
+		registerIdle(srv, quiet)
+
+		connections := srv.takeIdleToPing(now.Add(-time.Minute))
+
+		testutil.AssertDeepEquals(t, connections, []db.Connection{quiet})
+		testutil.AssertIntEqual(t, srv.numIdle(), 1)
+		testutil.AssertIntEqual(t, srv.numBusy(), 0)
+		testutil.AssertIntEqual(t, srv.stats(now).InUse, 0)
+		testutil.AssertIntEqual(t, srv.size(), 2)
+		testutil.AssertTrue(t, srv.returnPinged(quiet))
+		testutil.AssertIntEqual(t, srv.numIdle(), 2)
+		testutil.AssertIntEqual(t, srv.numBusy(), 0)
+	})
+
+	outer.Run("removes pinged connections found dead", func(t *testing.T) {
+		srv := NewServer()
+		conn := &testutil.ConnFake{Alive: true, Idle: time.Now().Add(-time.Hour)}
+		registerIdle(srv, conn)
+		srv.takeIdleToPing(time.Now())
+		conn.Alive = false
+
+		testutil.AssertFalse(t, srv.returnPinged(conn))
+		testutil.AssertIntEqual(t, srv.size(), 0)
+	})
 }
 
 func registerIdle(srv *server, connection db.Connection) {
 	srv.registerBusy(connection)
-	srv.returnBusy(connection)
+	srv.returnBusy(connection, time.Now())
 }
@@ -65,6 +65,11 @@ type State struct {
 	deadErrors       int
 	skipSleep        bool
 	OnDeadConnection func(server string) error
+	// OnSecurityError is called with security errors and returns true when the authentication information
+	// has been refreshed, which makes expired tokens retryable
+	OnSecurityError func(err *db.Neo4jError) bool
+	// OnRetry is called with the retry cause before every new attempt, including the delay before the attempt
+	OnRetry func(cause string)
 }
 
 func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, isCommitting bool) {
@@ -85,10 +90,19 @@ func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, i
 	// Reset after determined to evaluate this error
 	s.LastErrWasRetryable = false
 
-	if neo4jErr, ok := err.(*db.Neo4jError); ok && neo4jErr.IsAuthenticationFailed() {
-		s.cause = "Authentication failed"
-		s.stop = true
-		return
+	var neo4jErr *db.Neo4jError
+	if errors.As(err, &neo4jErr) && neo4jErr.IsSecurityError() {
+		refreshed := s.OnSecurityError != nil && s.OnSecurityError(neo4jErr)
+		if refreshed && neo4jErr.IsTokenExpired() {
+			s.LastErrWasRetryable = true
+			s.cause = "Token expired"
+			return
+		}
+		if neo4jErr.IsAuthenticationFailed() {
+			s.cause = "Authentication failed"
+			s.stop = true
+			return
+		}
 	}
 
 	// Failed to connect
@@ -132,6 +146,12 @@ func (s *State) OnFailure(ctx context.Context, conn idb.Connection, err error, i
 			s.cause = "Transient error"
 			return
 		}
+		if dbErr.IsDatabaseNotFound() {
+			// The database might have been resolved from an outdated home database
+			if err := s.Router.Invalidate(ctx, s.DatabaseName); err != nil {
+				s.Log.Warnf(s.LogName, s.LogId, "Could not invalidate routing table of '%s': %s", s.DatabaseName, err)
+			}
+		}
 	}
 
 	s.stop = true
@@ -151,6 +171,9 @@ func (s *State) Continue() bool {
 
 	// Retry after optional sleep
 	if !s.stop {
+		if s.OnRetry != nil {
+			s.OnRetry(s.cause)
+		}
 		if s.skipSleep {
 			s.Log.Debugf(s.LogName, s.LogId, "Retrying transaction (%s): %s", s.cause, s.LastErr)
 		} else {
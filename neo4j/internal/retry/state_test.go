@@ -45,6 +45,7 @@ type TStateInvocation struct {
 	expectRouterInvalidatedServer string
 	expectLastErrWasRetryable     bool
 	expectLastErrType             error
+	securityErrorHandled          bool
 }
 
 func TestState(outer *testing.T) {
@@ -56,10 +57,12 @@ func TestState(outer *testing.T) {
 		maxDead      = 2
 		dbName       = "thedb"
 		// a single server can be reused here since the router is a fake impl
-		serverName     = "somehost:9999"
-		authErr        = &db.Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"}
-		clusterErr     = &db.Neo4jError{Code: "Neo.ClientError.Cluster.NotALeader"}
-		dbTransientErr = &db.Neo4jError{Code: "Neo.TransientError.Some.Some"}
+		serverName      = "somehost:9999"
+		authErr         = &db.Neo4jError{Code: "Neo.ClientError.Security.Unauthorized"}
+		tokenExpiredErr = &db.Neo4jError{Code: "Neo.ClientError.Security.TokenExpired"}
+		clusterErr      = &db.Neo4jError{Code: "Neo.ClientError.Cluster.NotALeader"}
+		dbTransientErr  = &db.Neo4jError{Code: "Neo.TransientError.Some.Some"}
+		dbNotFoundErr   = &db.Neo4jError{Code: "Neo.ClientError.Database.DatabaseNotFound"}
 	)
 
 	testCases := map[string][]TStateInvocation{
@@ -108,6 +111,10 @@ func TestState(outer *testing.T) {
 			{conn: &testutil.ConnFake{Alive: true}, err: clusterErr, expectContinued: false, now: overTime,
 				expectLastErrWasRetryable: true},
 		},
+		"Database not found": {
+			{conn: &testutil.ConnFake{Alive: true}, err: dbNotFoundErr, expectContinued: false,
+				expectRouterInvalidated: true, expectRouterInvalidatedDb: dbName},
+		},
 		"Database transient error": {
 			{conn: &testutil.ConnFake{Alive: true}, err: dbTransientErr, expectContinued: true,
 				expectLastErrWasRetryable: true},
@@ -136,6 +143,18 @@ func TestState(outer *testing.T) {
 			{conn: nil, err: authErr, expectContinued: false,
 				expectLastErrWasRetryable: false},
 		},
+		"Does not retry on auth errors handled by the token manager": {
+			{conn: nil, err: authErr, securityErrorHandled: true, expectContinued: false,
+				expectLastErrWasRetryable: false},
+		},
+		"Retry on expired token refreshed by the token manager": {
+			{conn: &testutil.ConnFake{Alive: true}, err: tokenExpiredErr, securityErrorHandled: true,
+				expectContinued: true, expectLastErrWasRetryable: true},
+		},
+		"Does not retry on expired token not refreshed by the token manager": {
+			{conn: &testutil.ConnFake{Alive: true}, err: tokenExpiredErr, expectContinued: false,
+				expectLastErrWasRetryable: false},
+		},
 	}
 
 	ctx := context.Background()
@@ -162,12 +181,23 @@ func TestState(outer *testing.T) {
 				state.OnDeadConnection = func(server string) error {
 					return router.InvalidateReader(ctx, dbName, server)
 				}
+				securityErrorHandled := invocation.securityErrorHandled
+				state.OnSecurityError = func(*db.Neo4jError) bool {
+					return securityErrorHandled
+				}
+				retryCause := ""
+				state.OnRetry = func(cause string) {
+					retryCause = cause
+				}
 
 				state.OnFailure(ctx, invocation.conn, invocation.err, invocation.isCommitting)
 				continued := state.Continue()
 				if continued != invocation.expectContinued {
 					t.Errorf("Expected continue to return %v but returned %v", invocation.expectContinued, continued)
 				}
+				if continued != (retryCause != "") {
+					t.Errorf("Expected retry hook to be called with a cause only when continuing, got cause %q", retryCause)
+				}
 				if invocation.expectRouterInvalidated != router.Invalidated ||
 					invocation.expectRouterInvalidatedDb != router.InvalidatedDb ||
 					invocation.expectRouterInvalidatedServer != router.InvalidatedServer {
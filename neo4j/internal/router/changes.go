@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package router
+
+import (
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+)
+
+// Reasons of routing table changes
+const (
+	// TableUpdated is the reason of changes made by reading a routing table
+	TableUpdated = "updated"
+	// ServerRemoved is the reason of changes made by removing a reader or a writer that failed
+	ServerRemoved = "server removed"
+	// TableRemoved is the reason of changes made by removing expired routing tables
+	TableRemoved = "removed"
+)
+
+// TableSnapshot is a copy of a routing table along with its expiry
+type TableSnapshot struct {
+	Table     *db.RoutingTable
+	ExpiresAt time.Time
+}
+
+// ServersDiff lists the servers added and removed by a routing table change
+type ServersDiff struct {
+	Added   []string
+	Removed []string
+}
+
+// TableChange describes a change of the routing table of a database
+type TableChange struct {
+	Reason   string
+	Database string
+	Policy   string
+	// Before is nil when there was no routing table
+	Before *TableSnapshot
+	// After is nil when the routing table is removed
+	After   *TableSnapshot
+	Routers ServersDiff
+	Readers ServersDiff
+	Writers ServersDiff
+}
+
+// TableListener is notified of the routing table changes
+type TableListener func(change TableChange)
+
+// SetTableListener registers the listener notified of the routing table changes.
+// The listener is called without holding the router lock, possibly from several goroutines at the same time.
+func (r *Router) SetTableListener(listener TableListener) {
+	r.listener = listener
+}
+
+// Returns the change between the snapshots, false when the servers are the same
+func newTableChange(reason string, key routingKey, before, after *TableSnapshot) (TableChange, bool) {
+	change := TableChange{Reason: reason, Database: key.database, Policy: key.policy, Before: before, After: after}
+	var beforeTable, afterTable db.RoutingTable
+	if before != nil {
+		beforeTable = *before.Table
+	}
+	if after != nil {
+		afterTable = *after.Table
+	}
+	change.Routers = diffServers(beforeTable.Routers, afterTable.Routers)
+	change.Readers = diffServers(beforeTable.Readers, afterTable.Readers)
+	change.Writers = diffServers(beforeTable.Writers, afterTable.Writers)
+	changed := before == nil || after == nil
+	for _, diff := range []ServersDiff{change.Routers, change.Readers, change.Writers} {
+		changed = changed || len(diff.Added) > 0 || len(diff.Removed) > 0
+	}
+	return change, changed
+}
+
+func diffServers(before, after []string) ServersDiff {
+	var diff ServersDiff
+	for _, server := range after {
+		if !containsServer(before, server) {
+			diff.Added = append(diff.Added, server)
+		}
+	}
+	for _, server := range before {
+		if !containsServer(after, server) {
+			diff.Removed = append(diff.Removed, server)
+		}
+	}
+	return diff
+}
+
+func containsServer(servers []string, server string) bool {
+	for _, s := range servers {
+		if s == server {
+			return true
+		}
+	}
+	return false
+}
+
+// Collects the routing table changes made while holding the router lock, so that the listener is notified
+// once the lock is released
+type tableChanges struct {
+	listener TableListener
+	changes  []TableChange
+}
+
+func (r *Router) newTableChanges() *tableChanges {
+	return &tableChanges{listener: r.listener}
+}
+
+// Copies the routing table when there is a listener to notify
+func (c *tableChanges) snapshot(dbRouter *databaseRouter) *TableSnapshot {
+	if c.listener == nil || dbRouter == nil {
+		return nil
+	}
+	return &TableSnapshot{Table: copyTable(dbRouter.table), ExpiresAt: time.Unix(dbRouter.dueUnix, 0)}
+}
+
+// Records the change of the routing table, the before snapshot must be taken before changing the table
+func (c *tableChanges) record(reason string, key routingKey, before, after *TableSnapshot) {
+	if c.listener == nil {
+		return
+	}
+	if change, changed := newTableChange(reason, key, before, after); changed {
+		c.changes = append(c.changes, change)
+	}
+}
+
+func (c *tableChanges) notify() {
+	for _, change := range c.changes {
+		c.listener(change)
+	}
+}
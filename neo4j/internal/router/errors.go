@@ -20,6 +20,8 @@
 package router
 
 import (
+	"context"
+	"errors"
 	"fmt"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
@@ -45,3 +47,15 @@ func wrapError(server string, err error) error {
 	}
 	return &ReadRoutingTableError{server: server, err: err}
 }
+
+func isDatabaseNotFound(err error) bool {
+	neo4jErr, isNeo4jErr := err.(*db.Neo4jError)
+	return isNeo4jErr && neo4jErr.IsDatabaseNotFound()
+}
+
+func isContextError(err error) bool {
+	if routingErr, isRoutingErr := err.(*ReadRoutingTableError); isRoutingErr {
+		err = routingErr.err
+	}
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package router
+
+import (
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+)
+
+const principalKey = "principal"
+
+// Home databases depend on the authenticated user and the impersonated user, if any
+type homeDatabaseKey struct {
+	scheme           string
+	principal        string
+	impersonatedUser string
+}
+
+type homeDatabase struct {
+	name    string
+	dueUnix int64
+}
+
+// Returns the key of the home database cache, the user of a session token is identified by the principal of the
+// token. Nil and driver tokens stand for the driver user, whatever the token. Returns false when the user of a
+// session token cannot be identified, for instance for bearer tokens, in which case the home database is not cached.
+func newHomeDatabaseKey(auth *db.ReAuthToken, impersonatedUser string) (homeDatabaseKey, bool) {
+	key := homeDatabaseKey{impersonatedUser: impersonatedUser}
+	if auth == nil || !auth.FromSession {
+		return key, true
+	}
+	principal, ok := auth.Token[principalKey].(string)
+	if !ok {
+		return key, false
+	}
+	key.scheme, _ = auth.Token["scheme"].(string)
+	key.principal = principal
+	return key, true
+}
+
+// Returns the cached home database, the caller must hold the routing table lock
+func (r *Router) cachedHomeDatabase(key homeDatabaseKey) (string, bool) {
+	homeDb := r.homeDbs[key]
+	if homeDb == nil || r.now().Unix() >= homeDb.dueUnix {
+		return "", false
+	}
+	return homeDb.name, true
+}
+
+// Forgets every home database resolved to the provided database, the caller must hold the routing table lock
+func (r *Router) forgetHomeDatabase(database string) {
+	for key, homeDb := range r.homeDbs {
+		if homeDb.name == database {
+			delete(r.homeDbs, key)
+		}
+	}
+}
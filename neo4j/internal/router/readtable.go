@@ -21,40 +21,95 @@ package router
 
 import (
 	"context"
+	"time"
+
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
 )
 
+// At most maxParallelRouters routers are asked for the routing table at the same time
+const maxParallelRouters = 3
+
+// Delay after which the next router is tried while the previous ones have not answered yet
+const routerFanOutDelay = 500 * time.Millisecond
+
+type routerResult struct {
+	table *db.RoutingTable
+	err   error
+}
+
 // Tries to read routing table from any of the specified routers using new or existing connection
 // from the supplied pool.
+// Routers are tried in order, the next router is tried as soon as the previous one fails or when it does
+// not answer within routerFanOutDelay, so that a slow router does not hold up the routing table.
+// The first routing table read wins, the routers still being asked are cancelled.
 func readTable(ctx context.Context, connectionPool Pool, routers []string, routerContext map[string]string, bookmarks []string,
-	database, impersonatedUser string, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
+	database, impersonatedUser string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
 	// Preserve last error to be returned, set a default for case of no routers
 	var err error = &ReadRoutingTableError{}
+	if len(routers) == 0 {
+		return nil, err
+	}
+
+	fanOutCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// Buffered so that the routers still being asked when returning do not block
+	results := make(chan routerResult, len(routers))
+	next, inFlight := 0, 0
+	askNext := func() {
+		router := routers[next]
+		next++
+		inFlight++
+		go func() {
+			table, err := readTableFrom(fanOutCtx, connectionPool, router, routerContext, bookmarks, database, impersonatedUser, auth, boltLogger)
+			results <- routerResult{table: table, err: err}
+		}()
+	}
 
-	// Try the routers one at the time since some of them might no longer support routing and we
-	// can't force the pool to not re-use these when putting them back in the pool and retrieving
-	// another db.
-	for _, router := range routers {
-		var conn db.Connection
-		if conn, err = connectionPool.Borrow(ctx, []string{router}, true, boltLogger, pool.DefaultLivenessCheckThreshold); err != nil {
+	askNext()
+	for inFlight > 0 {
+		var fanOut <-chan time.Time
+		if next < len(routers) && inFlight < maxParallelRouters {
+			fanOut = time.After(routerFanOutDelay)
+		}
+		select {
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				return result.table, nil
+			}
+			err = result.err
 			// Check if failed due to context timing out
 			if ctx.Err() != nil {
-				return nil, wrapError(router, ctx.Err())
+				return nil, err
+			}
+			if next < len(routers) {
+				askNext()
 			}
-			err = wrapError(router, err)
-			continue
+		case <-fanOut:
+			askNext()
 		}
+	}
+	return nil, err
+}
 
-		// We have a connection to the "router"
-		var table *db.RoutingTable
-		table, err = conn.GetRoutingTable(ctx, routerContext, bookmarks, database, impersonatedUser)
-		connectionPool.Return(ctx, conn)
-		if err == nil {
-			return table, nil
+func readTableFrom(ctx context.Context, connectionPool Pool, router string, routerContext map[string]string, bookmarks []string,
+	database, impersonatedUser string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
+	conn, err := connectionPool.Borrow(ctx, []string{router}, true, boltLogger, pool.DefaultLivenessCheckThreshold, auth)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, wrapError(router, ctx.Err())
 		}
-		err = wrapError(router, err)
+		return nil, wrapError(router, err)
 	}
-	return nil, err
+
+	// We have a connection to the "router"
+	table, err := conn.GetRoutingTable(ctx, routerContext, bookmarks, database, impersonatedUser)
+	// The context is cancelled as soon as another router answers, the connection must still go back to the pool
+	connectionPool.Return(context.Background(), conn)
+	if err != nil {
+		return nil, wrapError(router, err)
+	}
+	return table, nil
 }
@@ -23,8 +23,11 @@ import (
 	"context"
 	"errors"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
@@ -175,7 +178,7 @@ func TestReadTableTable(ot *testing.T) {
 		ot.Run(c.name, func(t *testing.T) {
 			ctx, cancel := context.WithCancel(context.Background())
 			c.pool.cancel = cancel
-			table, err := readTable(ctx, c.pool, c.routers, nil, nil, "dbname", "", nil)
+			table, err := readTable(ctx, c.pool, c.routers, nil, nil, "dbname", "", nil, nil)
 			c.assert(t, table, err)
 			if err != nil && c.assertErr != nil {
 				c.assertErr(t, err)
@@ -189,3 +192,58 @@ func TestReadTableTable(ot *testing.T) {
 		})
 	}
 }
+
+func TestReadTableFansOutToSlowRouters(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	var asked []string
+	askedMut := sync.Mutex{}
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (idb.Connection, error) {
+			askedMut.Lock()
+			asked = append(asked, names[0])
+			askedMut.Unlock()
+			if names[0] == "router3" {
+				return &testutil.ConnFake{Table: &idb.RoutingTable{Readers: []string{"reader"}}}, nil
+			}
+			<-release
+			return nil, errors.New("too late")
+		},
+	}
+
+	table, err := readTable(context.Background(), pool, []string{"router1", "router2", "router3", "router4"}, nil, nil, "dbname", "", nil, nil)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, table.Readers, []string{"reader"})
+	askedMut.Lock()
+	defer askedMut.Unlock()
+	testutil.AssertDeepEquals(t, asked, []string{"router1", "router2", "router3"})
+}
+
+func TestReadTableReturnsConnectionsOfRoutersAnsweringLate(t *testing.T) {
+	ctx := context.Background()
+	release := make(chan struct{})
+	connect := func(_ context.Context, name string, _ *idb.ReAuthToken, _ log.BoltLogger) (idb.Connection, error) {
+		conn := &testutil.ConnFake{Name: name, Alive: true, Birth: time.Now(), Table: &idb.RoutingTable{Readers: []string{name}}}
+		if name == "router1" {
+			conn.TableHook = func() { <-release }
+		}
+		return conn, nil
+	}
+	connectionPool := pool.New(10, time.Hour, connect, &log.Void{}, "pool id")
+	defer connectionPool.Close(ctx)
+
+	table, err := readTable(ctx, connectionPool, []string{"router1", "router2"}, nil, nil, "dbname", "", nil, nil)
+	close(release)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, table.Readers, []string{"router2"})
+	deadline := time.Now().Add(time.Second)
+	for connectionPool.Metrics()["router1"].Idle != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Connection of late router was not returned: %+v", connectionPool.Metrics()["router1"])
+		}
+		time.Sleep(time.Millisecond)
+	}
+	testutil.AssertDeepEquals(t, connectionPool.Metrics()["router1"].InUse, int64(0))
+}
@@ -32,31 +32,72 @@ import (
 const missingWriterRetries = 100
 const missingReaderRetries = 100
 
+// PolicyKey is the key of the server-side routing policy in the routing context
+const PolicyKey = "policy"
+
+// Routing tables are refreshed in the background during the last fifth of their time to live
+const refreshAheadDivisor = 5
+
+// Maximum duration of routing table refreshes in the background
+const backgroundRefreshTimeout = 30 * time.Second
+
 type databaseRouter struct {
-	dueUnix int64
-	table   *db.RoutingTable
+	dueUnix     int64
+	refreshUnix int64
+	table       *db.RoutingTable
+}
+
+func newDatabaseRouter(table *db.RoutingTable, now time.Time) *databaseRouter {
+	dueUnix := now.Add(time.Duration(table.TimeToLive) * time.Second).Unix()
+	return &databaseRouter{
+		table:       table,
+		dueUnix:     dueUnix,
+		refreshUnix: dueUnix - int64(table.TimeToLive/refreshAheadDivisor),
+	}
+}
+
+// A routing table read in progress, concurrent sessions needing the same table wait for it instead of reading it again
+type tableRead struct {
+	done  chan struct{}
+	table *db.RoutingTable
+	err   error
+}
+
+// Routing tables depend on the routing policy, they are cached per database and policy
+type routingKey struct {
+	database string
+	policy   string
 }
 
 // Router is thread safe
 type Router struct {
 	routerContext map[string]string
+	policy        string
 	pool          Pool
-	dbRouters     map[string]*databaseRouter
+	dbRouters     map[routingKey]*databaseRouter
+	tableReads    map[routingKey]*tableRead
+	homeDbs       map[homeDatabaseKey]*homeDatabase
 	dbRoutersMut  racing.Mutex
 	now           func() time.Time
 	sleep         func(time.Duration)
 	rootRouter    string
 	getRouters    func() []string
+	resolver      Resolver
+	listener      TableListener
 	log           log.Logger
 	logId         string
 }
 
+// Resolver resolves the address of a router into the addresses of the servers to connect to
+type Resolver func(ctx context.Context, address string) ([]string, error)
+
 type Pool interface {
 	// Borrow acquires a connection from the provided list of servers
 	// If all connections are busy and the pool is full, calls to Borrow may wait for a connection to become idle
 	// If a connection has been idle for longer than idlenessThreshold, it will be reset
 	// to check if it's still alive.
-	Borrow(ctx context.Context, servers []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration) (db.Connection, error)
+	// The borrowed connection is authenticated with the provided token, the default driver token is used when nil.
+	Borrow(ctx context.Context, servers []string, wait bool, boltLogger log.BoltLogger, idlenessThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error)
 	Return(ctx context.Context, c db.Connection) error
 }
 
@@ -65,8 +106,11 @@ func New(rootRouter string, getRouters func() []string, routerContext map[string
 		rootRouter:    rootRouter,
 		getRouters:    getRouters,
 		routerContext: routerContext,
+		policy:        routerContext[PolicyKey],
 		pool:          pool,
-		dbRouters:     make(map[string]*databaseRouter),
+		dbRouters:     make(map[routingKey]*databaseRouter),
+		tableReads:    make(map[routingKey]*tableRead),
+		homeDbs:       make(map[homeDatabaseKey]*homeDatabase),
 		dbRoutersMut:  racing.NewMutex(),
 		now:           time.Now,
 		sleep:         time.Sleep,
@@ -77,76 +121,192 @@ func New(rootRouter string, getRouters func() []string, routerContext map[string
 	return r
 }
 
-func (r *Router) readTable(ctx context.Context, dbRouter *databaseRouter, bookmarks []string, database, impersonatedUser string, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
+// WithPolicy returns a router reading routing tables for the provided server-side routing policy.
+// The returned router shares its cache of routing tables with r.
+func (r *Router) WithPolicy(policy string) *Router {
+	if policy == r.policy {
+		return r
+	}
+	routerContext := make(map[string]string, len(r.routerContext)+1)
+	for k, v := range r.routerContext {
+		routerContext[k] = v
+	}
+	routerContext[PolicyKey] = policy
+	policyRouter := *r
+	policyRouter.routerContext = routerContext
+	policyRouter.policy = policy
+	return &policyRouter
+}
+
+// SetResolver registers the resolver applied to the initial router and to the routers of the routing tables
+func (r *Router) SetResolver(resolver Resolver) {
+	r.resolver = resolver
+}
+
+// Resolves the routers, routers that cannot be resolved are used as they are
+func (r *Router) resolveRouters(ctx context.Context, routers []string) []string {
+	if r.resolver == nil {
+		return routers
+	}
+	resolved := make([]string, 0, len(routers))
+	known := make(map[string]bool, len(routers))
+	for _, router := range routers {
+		addresses, err := r.resolver(ctx, router)
+		if err != nil {
+			r.log.Warnf(log.Router, r.logId, "Could not resolve router %s: %s", router, err)
+			addresses = []string{router}
+		} else {
+			r.log.Debugf(log.Router, r.logId, "Resolved router %s to %v", router, addresses)
+		}
+		for _, address := range addresses {
+			if !known[address] {
+				known[address] = true
+				resolved = append(resolved, address)
+			}
+		}
+	}
+	return resolved
+}
+
+func (r *Router) key(database string) routingKey {
+	return routingKey{database: database, policy: r.policy}
+}
+
+func (r *Router) readTable(ctx context.Context, dbRouter *databaseRouter, bookmarks []string, database, impersonatedUser string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
 	var (
 		table *db.RoutingTable
 		err   error
 	)
+	logger := log.WithDatabase(r.log, database)
 
 	// Try last known set of routers if there are any
 	if dbRouter != nil && len(dbRouter.table.Routers) > 0 {
-		routers := dbRouter.table.Routers
-		r.log.Infof(log.Router, r.logId, "Reading routing table for '%s' from previously known routers: %v", database, routers)
-		table, err = readTable(ctx, r.pool, routers, r.routerContext, bookmarks, database, impersonatedUser, boltLogger)
+		routers := r.resolveRouters(ctx, dbRouter.table.Routers)
+		logger.Infof(log.Router, r.logId, "Reading routing table for '%s' from previously known routers: %v", database, routers)
+		table, err = readTable(ctx, r.pool, routers, r.routerContext, bookmarks, database, impersonatedUser, auth, boltLogger)
 	}
 
 	// Try initial router if no routers or failed
 	if table == nil {
-		r.log.Infof(log.Router, r.logId, "Reading routing table from initial router: %s", r.rootRouter)
-		table, err = readTable(ctx, r.pool, []string{r.rootRouter}, r.routerContext, bookmarks, database, impersonatedUser, boltLogger)
+		routers := r.resolveRouters(ctx, []string{r.rootRouter})
+		logger.Infof(log.Router, r.logId, "Reading routing table from initial router: %s", r.rootRouter)
+		table, err = readTable(ctx, r.pool, routers, r.routerContext, bookmarks, database, impersonatedUser, auth, boltLogger)
 	}
 
 	// Use hook to retrieve possibly different set of routers and retry
 	if table == nil && r.getRouters != nil {
 		routers := r.getRouters()
-		r.log.Infof(log.Router, r.logId, "Reading routing table for '%s' from custom routers: %v", routers)
-		table, err = readTable(ctx, r.pool, routers, r.routerContext, bookmarks, database, impersonatedUser, boltLogger)
+		logger.Infof(log.Router, r.logId, "Reading routing table for '%s' from custom routers: %v", database, routers)
+		table, err = readTable(ctx, r.pool, routers, r.routerContext, bookmarks, database, impersonatedUser, auth, boltLogger)
 	}
 
 	if err != nil {
-		r.log.Error(log.Router, r.logId, err)
+		logger.Error(log.Router, r.logId, err)
 		return nil, err
 	}
 
 	if table == nil {
 		// Safeguard for logical error somewhere else
 		err = errors.New("no error and no table")
-		r.log.Error(log.Router, r.logId, err)
+		logger.Error(log.Router, r.logId, err)
 		return nil, err
 	}
 	return table, nil
 }
 
-func (r *Router) getOrReadTable(ctx context.Context, bookmarks []string, database string, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
-	now := r.now()
+// Returns the routing table of the database, reading it when it is missing or expired.
+// The lock is not held while reading a routing table, so that a slow router does not hold up the other databases.
+// Only one read per database is in progress at a time, the other sessions needing the table wait for it.
+func (r *Router) getOrReadTable(ctx context.Context, bookmarks []string, database string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (*db.RoutingTable, error) {
+	key := r.key(database)
+	for {
+		if !r.dbRoutersMut.TryLock(ctx) {
+			return nil, racing.LockTimeoutError("could not acquire router lock in time when getting routing table")
+		}
+		now := r.now().Unix()
+		dbRouter := r.dbRouters[key]
+		if dbRouter != nil && now < dbRouter.dueUnix {
+			// Serve the still valid table while refreshing it ahead of its expiry
+			if now >= dbRouter.refreshUnix && r.tableReads[key] == nil {
+				r.refreshInBackground(key, dbRouter, bookmarks, database, auth)
+			}
+			r.dbRoutersMut.Unlock()
+			return dbRouter.table, nil
+		}
+		read, waiting := r.tableReads[key]
+		if !waiting {
+			read = r.startTableRead(key)
+		}
+		r.dbRoutersMut.Unlock()
 
-	if !r.dbRoutersMut.TryLock(ctx) {
-		return nil, racing.LockTimeoutError("could not acquire router lock in time when getting routing table")
+		if !waiting {
+			r.completeTableRead(ctx, read, key, dbRouter, bookmarks, database, auth, boltLogger)
+			return read.table, read.err
+		}
+		select {
+		case <-read.done:
+		case <-ctx.Done():
+			return nil, racing.LockTimeoutError("routing table was not read in time")
+		}
+		// Read again when the session that read the table gave up
+		if read.err != nil && isContextError(read.err) && ctx.Err() == nil {
+			continue
+		}
+		return read.table, read.err
 	}
-	defer r.dbRoutersMut.Unlock()
+}
 
-	dbRouter := r.dbRouters[database]
-	if dbRouter != nil && now.Unix() < dbRouter.dueUnix {
-		return dbRouter.table, nil
-	}
+// Registers a read of the routing table, the caller must hold the lock
+func (r *Router) startTableRead(key routingKey) *tableRead {
+	read := &tableRead{done: make(chan struct{})}
+	r.tableReads[key] = read
+	return read
+}
 
-	table, err := r.readTable(ctx, dbRouter, bookmarks, database, "", boltLogger)
+// Reads the routing table without holding the lock, stores it and notifies the sessions waiting for it
+func (r *Router) completeTableRead(ctx context.Context, read *tableRead, key routingKey, dbRouter *databaseRouter, bookmarks []string, database string, auth *db.ReAuthToken, boltLogger log.BoltLogger) {
+	table, err := r.readTable(ctx, dbRouter, bookmarks, database, "", auth, boltLogger)
+	now := r.now()
+
+	changes := r.newTableChanges()
+	// Deferred before unlocking, so that the listener is notified once the lock is released
+	defer changes.notify()
+	// The read must be unregistered whatever the state of the context
+	r.dbRoutersMut.TryLock(context.Background())
+	defer r.dbRoutersMut.Unlock()
+	delete(r.tableReads, key)
+	read.table, read.err = table, err
+	close(read.done)
 	if err != nil {
-		return nil, err
+		if isDatabaseNotFound(err) {
+			r.forgetHomeDatabase(database)
+		}
+		return
 	}
 
 	// Store the routing table
-	r.dbRouters[database] = &databaseRouter{
-		table:   table,
-		dueUnix: now.Add(time.Duration(table.TimeToLive) * time.Second).Unix(),
-	}
-	r.log.Debugf(log.Router, r.logId, "New routing table for '%s', TTL %d", database, table.TimeToLive)
+	before := changes.snapshot(r.dbRouters[key])
+	r.dbRouters[key] = newDatabaseRouter(table, now)
+	changes.record(TableUpdated, key, before, changes.snapshot(r.dbRouters[key]))
+	log.WithDatabase(r.log, database).Debugf(log.Router, r.logId, "New routing table for '%s', TTL %d", database, table.TimeToLive)
+}
 
-	return table, nil
+// Refreshes the routing table, the current table keeps being used meanwhile. The caller must hold the lock
+func (r *Router) refreshInBackground(key routingKey, dbRouter *databaseRouter, bookmarks []string, database string, auth *db.ReAuthToken) {
+	read := r.startTableRead(key)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundRefreshTimeout)
+		defer cancel()
+		log.WithDatabase(r.log, database).Debugf(log.Router, r.logId, "Refreshing routing table for '%s' ahead of expiry", database)
+		r.completeTableRead(ctx, read, key, dbRouter, bookmarks, database, auth, nil)
+		if read.err != nil {
+			log.WithDatabase(r.log, database).Warnf(log.Router, r.logId, "Could not refresh routing table for '%s' ahead of expiry: %s", database, read.err)
+		}
+	}()
 }
 
-func (r *Router) Readers(ctx context.Context, bookmarks []string, database string, boltLogger log.BoltLogger) ([]string, error) {
-	table, err := r.getOrReadTable(ctx, bookmarks, database, boltLogger)
+func (r *Router) Readers(ctx context.Context, bookmarks []string, database string, auth *db.ReAuthToken, boltLogger log.BoltLogger) ([]string, error) {
+	table, err := r.getOrReadTable(ctx, bookmarks, database, auth, boltLogger)
 	if err != nil {
 		return nil, err
 	}
@@ -163,7 +323,7 @@ func (r *Router) Readers(ctx context.Context, bookmarks []string, database strin
 			return nil, err
 		}
 		r.sleep(100 * time.Millisecond)
-		table, err = r.getOrReadTable(ctx, bookmarks, database, boltLogger)
+		table, err = r.getOrReadTable(ctx, bookmarks, database, auth, boltLogger)
 		if err != nil {
 			return nil, err
 		}
@@ -175,8 +335,8 @@ func (r *Router) Readers(ctx context.Context, bookmarks []string, database strin
 	return table.Readers, nil
 }
 
-func (r *Router) Writers(ctx context.Context, bookmarks []string, database string, boltLogger log.BoltLogger) ([]string, error) {
-	table, err := r.getOrReadTable(ctx, bookmarks, database, boltLogger)
+func (r *Router) Writers(ctx context.Context, bookmarks []string, database string, auth *db.ReAuthToken, boltLogger log.BoltLogger) ([]string, error) {
+	table, err := r.getOrReadTable(ctx, bookmarks, database, auth, boltLogger)
 	if err != nil {
 		return nil, err
 	}
@@ -193,7 +353,7 @@ func (r *Router) Writers(ctx context.Context, bookmarks []string, database strin
 			return nil, err
 		}
 		r.sleep(100 * time.Millisecond)
-		table, err = r.getOrReadTable(ctx, bookmarks, database, boltLogger)
+		table, err = r.getOrReadTable(ctx, bookmarks, database, auth, boltLogger)
 		if err != nil {
 			return nil, err
 		}
@@ -205,80 +365,171 @@ func (r *Router) Writers(ctx context.Context, bookmarks []string, database strin
 	return table.Writers, nil
 }
 
-func (r *Router) GetNameOfDefaultDatabase(ctx context.Context, bookmarks []string, user string, boltLogger log.BoltLogger) (string, error) {
-	table, err := r.readTable(ctx, nil, bookmarks, db.DefaultDatabase, user, boltLogger)
+// GetNameOfDefaultDatabase resolves the home database of the user, or of the impersonated user when provided.
+// Home databases are cached per user for the time to live of the routing table they are resolved with.
+func (r *Router) GetNameOfDefaultDatabase(ctx context.Context, bookmarks []string, user string, auth *db.ReAuthToken, boltLogger log.BoltLogger) (string, error) {
+	homeDbKey, cacheable := newHomeDatabaseKey(auth, user)
+	if cacheable {
+		if !r.dbRoutersMut.TryLock(ctx) {
+			return "", racing.LockTimeoutError("could not acquire router lock in time when resolving home database")
+		}
+		name, found := r.cachedHomeDatabase(homeDbKey)
+		r.dbRoutersMut.Unlock()
+		if found {
+			return name, nil
+		}
+	}
+
+	table, err := r.readTable(ctx, nil, bookmarks, db.DefaultDatabase, user, auth, boltLogger)
 	if err != nil {
 		return "", err
 	}
 	// Store the fresh routing table as well to avoid another roundtrip to receive servers from session.
 	now := r.now()
+	changes := r.newTableChanges()
+	defer changes.notify()
 	if !r.dbRoutersMut.TryLock(ctx) {
 		return "", racing.LockTimeoutError("could not acquire router lock in time when resolving home database")
 	}
 	defer r.dbRoutersMut.Unlock()
-	r.dbRouters[table.DatabaseName] = &databaseRouter{
-		table:   table,
-		dueUnix: now.Add(time.Duration(table.TimeToLive) * time.Second).Unix(),
+	key := r.key(table.DatabaseName)
+	before := changes.snapshot(r.dbRouters[key])
+	dbRouter := newDatabaseRouter(table, now)
+	r.dbRouters[key] = dbRouter
+	changes.record(TableUpdated, key, before, changes.snapshot(dbRouter))
+	if cacheable {
+		r.homeDbs[homeDbKey] = &homeDatabase{name: table.DatabaseName, dueUnix: dbRouter.dueUnix}
 	}
 	r.log.Debugf(log.Router, r.logId, "New routing table when resolving home database: '%s', TTL %d", table.DatabaseName, table.TimeToLive)
 
 	return table.DatabaseName, err
 }
 
+// RoutingTable returns a copy of the routing table of the database along with its expiry,
+// the routing table is read first when it is missing or expired
+func (r *Router) RoutingTable(ctx context.Context, database string, auth *db.ReAuthToken) (*db.RoutingTable, time.Time, error) {
+	table, err := r.getOrReadTable(ctx, nil, database, auth, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !r.dbRoutersMut.TryLock(ctx) {
+		return nil, time.Time{}, racing.LockTimeoutError("could not acquire router lock in time when copying routing table")
+	}
+	defer r.dbRoutersMut.Unlock()
+	// The routing table might have been replaced meanwhile, the latest one is returned
+	dbRouter := r.dbRouters[r.key(database)]
+	if dbRouter == nil {
+		return copyTable(table), time.Time{}, nil
+	}
+	return copyTable(dbRouter.table), time.Unix(dbRouter.dueUnix, 0), nil
+}
+
+// RefreshRoutingTable reads the routing table of the database again, regardless of its expiry
+func (r *Router) RefreshRoutingTable(ctx context.Context, database string, auth *db.ReAuthToken) (*db.RoutingTable, time.Time, error) {
+	if err := r.Invalidate(ctx, database); err != nil {
+		return nil, time.Time{}, err
+	}
+	return r.RoutingTable(ctx, database, auth)
+}
+
+// Servers are removed in place from the routing tables, copies are needed to hand them out
+func copyTable(table *db.RoutingTable) *db.RoutingTable {
+	tableCopy := *table
+	tableCopy.Routers = append([]string(nil), table.Routers...)
+	tableCopy.Readers = append([]string(nil), table.Readers...)
+	tableCopy.Writers = append([]string(nil), table.Writers...)
+	return &tableCopy
+}
+
+// KnownServers returns the initial router along with the routers, readers and writers of every routing table
+func (r *Router) KnownServers(ctx context.Context) ([]string, error) {
+	if !r.dbRoutersMut.TryLock(ctx) {
+		return nil, racing.LockTimeoutError("could not acquire router lock in time when listing known servers")
+	}
+	defer r.dbRoutersMut.Unlock()
+	servers := []string{r.rootRouter}
+	known := map[string]bool{r.rootRouter: true}
+	for _, dbRouter := range r.dbRouters {
+		for _, roleServers := range [][]string{dbRouter.table.Routers, dbRouter.table.Readers, dbRouter.table.Writers} {
+			for _, server := range roleServers {
+				if !known[server] {
+					known[server] = true
+					servers = append(servers, server)
+				}
+			}
+		}
+	}
+	return servers, nil
+}
+
 func (r *Router) Context() map[string]string {
 	return r.routerContext
 }
 
 func (r *Router) Invalidate(ctx context.Context, database string) error {
-	r.log.Infof(log.Router, r.logId, "Invalidating routing table for '%s'", database)
+	log.WithDatabase(r.log, database).Infof(log.Router, r.logId, "Invalidating routing table for '%s'", database)
 	if !r.dbRoutersMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire router lock in time when invalidating database router")
 	}
 	defer r.dbRoutersMut.Unlock()
 	// Reset due time to the 70s, this will make next access refresh the routing table using
 	// last set of routers instead of the original one.
-	dbRouter := r.dbRouters[database]
+	dbRouter := r.dbRouters[r.key(database)]
 	if dbRouter != nil {
 		dbRouter.dueUnix = 0
 	}
+	// Users might no longer have the database as home database
+	r.forgetHomeDatabase(database)
 	return nil
 }
 
 func (r *Router) InvalidateWriter(ctx context.Context, db string, server string) error {
+	changes := r.newTableChanges()
+	defer changes.notify()
 	if !r.dbRoutersMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire router lock in time when getting routing table")
 	}
 	defer r.dbRoutersMut.Unlock()
 
-	router := r.dbRouters[db]
-	if router == nil {
-		return nil
-	}
-	writers := router.table.Writers
-	for i, writer := range writers {
-		if writer == server {
-			router.table.Writers = append(writers[0:i], writers[i+1:]...)
-			return nil
+	// The server is unavailable as writer whatever the routing policy
+	for key, router := range r.dbRouters {
+		if key.database != db {
+			continue
+		}
+		writers := router.table.Writers
+		for i, writer := range writers {
+			if writer == server {
+				before := changes.snapshot(router)
+				router.table.Writers = append(writers[0:i], writers[i+1:]...)
+				changes.record(ServerRemoved, key, before, changes.snapshot(router))
+				break
+			}
 		}
 	}
 	return nil
 }
 
 func (r *Router) InvalidateReader(ctx context.Context, db string, server string) error {
+	changes := r.newTableChanges()
+	defer changes.notify()
 	if !r.dbRoutersMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire router lock in time when invalidating reader")
 	}
 	defer r.dbRoutersMut.Unlock()
 
-	router := r.dbRouters[db]
-	if router == nil {
-		return nil
-	}
-	readers := router.table.Readers
-	for i, reader := range readers {
-		if reader == server {
-			router.table.Readers = append(readers[0:i], readers[i+1:]...)
-			return nil
+	// The server is unavailable as reader whatever the routing policy
+	for key, router := range r.dbRouters {
+		if key.database != db {
+			continue
+		}
+		readers := router.table.Readers
+		for i, reader := range readers {
+			if reader == server {
+				before := changes.snapshot(router)
+				router.table.Readers = append(readers[0:i], readers[i+1:]...)
+				changes.record(ServerRemoved, key, before, changes.snapshot(router))
+				break
+			}
 		}
 	}
 	return nil
@@ -287,6 +538,8 @@ func (r *Router) InvalidateReader(ctx context.Context, db string, server string)
 func (r *Router) CleanUp(ctx context.Context) error {
 	r.log.Debugf(log.Router, r.logId, "Cleaning up")
 	now := r.now().Unix()
+	changes := r.newTableChanges()
+	defer changes.notify()
 	if !r.dbRoutersMut.TryLock(ctx) {
 		return racing.LockTimeoutError("could not acquire router lock in time when invalidating reader")
 	}
@@ -294,8 +547,14 @@ func (r *Router) CleanUp(ctx context.Context) error {
 
 	for dbName, dbRouter := range r.dbRouters {
 		if now > dbRouter.dueUnix {
+			changes.record(TableRemoved, dbName, changes.snapshot(dbRouter), nil)
 			delete(r.dbRouters, dbName)
 		}
 	}
+	for key, homeDb := range r.homeDbs {
+		if now > homeDb.dueUnix {
+			delete(r.homeDbs, key)
+		}
+	}
 	return nil
 }
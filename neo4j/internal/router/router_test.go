@@ -23,9 +23,13 @@ package router
 import (
 	"context"
 	"errors"
+	"fmt"
+	neo4jdb "github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -65,14 +69,14 @@ func TestMultithreading(t *testing.T) {
 	wg.Add(2)
 	consumer := func() {
 		for i := 0; i < 30; i++ {
-			readers, err := router.Readers(context.Background(), nil, dbName, nil)
+			readers, err := router.Readers(context.Background(), nil, dbName, nil, nil)
 			if len(readers) != 2 {
 				t.Error("Wrong number of readers")
 			}
 			if err != nil {
 				t.Error(err)
 			}
-			writers, err := router.Writers(context.Background(), nil, dbName, nil)
+			writers, err := router.Writers(context.Background(), nil, dbName, nil, nil)
 			if len(writers) != 1 {
 				t.Error("Wrong number of writers")
 			}
@@ -115,26 +119,26 @@ func TestRespectsTimeToLiveAndInvalidate(t *testing.T) {
 
 	// First access should trigger initial table read
 	ctx := context.Background()
-	if _, err := router.Readers(ctx, nil, dbName, nil); err != nil {
+	if _, err := router.Readers(ctx, nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	assertNum(t, numfetch, 1, "Should have fetched initial")
 
 	// Second access with time set to same should not trigger a read
-	if _, err := router.Readers(ctx, nil, dbName, nil); err != nil {
+	if _, err := router.Readers(ctx, nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	assertNum(t, numfetch, 1, "Should not have have fetched")
 
 	// Third access with time passed table due should trigger fetch
 	n = n.Add(2 * time.Second)
-	if _, err := router.Readers(ctx, nil, dbName, nil); err != nil {
+	if _, err := router.Readers(ctx, nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	assertNum(t, numfetch, 2, "Should have have fetched")
 
 	// Just another one to make sure we're cached
-	if _, err := router.Readers(ctx, nil, dbName, nil); err != nil {
+	if _, err := router.Readers(ctx, nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	assertNum(t, numfetch, 2, "Should not have have fetched")
@@ -143,7 +147,7 @@ func TestRespectsTimeToLiveAndInvalidate(t *testing.T) {
 	if err := router.Invalidate(ctx, dbName); err != nil {
 		testutil.AssertNoError(t, err)
 	}
-	if _, err := router.Readers(ctx, nil, dbName, nil); err != nil {
+	if _, err := router.Readers(ctx, nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	assertNum(t, numfetch, 3, "Should have have fetched")
@@ -173,7 +177,7 @@ func TestUsesRootRouterWhenPreviousRoutersFails(t *testing.T) {
 	dbName := "dbname"
 
 	// First access should trigger initial table read from root router
-	if _, err := router.Readers(context.Background(), nil, dbName, nil); err != nil {
+	if _, err := router.Readers(context.Background(), nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	if borrows[0][0] != "rootRouter" {
@@ -181,7 +185,7 @@ func TestUsesRootRouterWhenPreviousRoutersFails(t *testing.T) {
 	}
 	// Next access should go to otherRouter
 	n = n.Add(2 * time.Second)
-	if _, err := router.Readers(context.Background(), nil, dbName, nil); err != nil {
+	if _, err := router.Readers(context.Background(), nil, dbName, nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 	if borrows[1][0] != "otherRouter" {
@@ -208,7 +212,7 @@ func TestUsesRootRouterWhenPreviousRoutersFails(t *testing.T) {
 		return &testutil.ConnFake{Table: &db.RoutingTable{TimeToLive: 1, Readers: []string{"aReader"}}}, nil
 	}
 	n = n.Add(2 * time.Second)
-	readers, err := router.Readers(context.Background(), nil, dbName, nil)
+	readers, err := router.Readers(context.Background(), nil, dbName, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -220,6 +224,48 @@ func TestUsesRootRouterWhenPreviousRoutersFails(t *testing.T) {
 	}
 }
 
+func TestResolvesRouters(t *testing.T) {
+	var tried []string
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			tried = append(tried, names...)
+			if names[0] == "unresolvable" {
+				return nil, errors.New("fail")
+			}
+			return &testutil.ConnFake{Table: &db.RoutingTable{
+				TimeToLive: 1,
+				Routers:    []string{"unresolvable", "cluster"},
+				Readers:    []string{"reader"},
+			}}, nil
+		},
+	}
+	nzero := time.Now()
+	n := nzero
+	router := New("cluster", func() []string { return []string{} }, nil, pool, logger, "routerid")
+	router.now = func() time.Time {
+		return n
+	}
+	router.SetResolver(func(_ context.Context, address string) ([]string, error) {
+		if address == "unresolvable" {
+			return nil, errors.New("unknown host")
+		}
+		return []string{"router1", "router2", "router1"}, nil
+	})
+	dbName := "dbname"
+
+	// Initial table read should resolve the root router
+	_, err := router.Readers(context.Background(), nil, dbName, nil, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, tried, []string{"router1"})
+
+	// Next read should resolve the known routers, using the unresolvable one as it is
+	tried = nil
+	n = n.Add(2 * time.Second)
+	_, err = router.Readers(context.Background(), nil, dbName, nil, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, tried, []string{"unresolvable", "router1"})
+}
+
 // Verify that when the routing table can not be retrieved from the root router, a callback
 // should be invoked to get backup routers.
 func TestUseGetRoutersHookWhenInitialRouterFails(t *testing.T) {
@@ -236,7 +282,7 @@ func TestUseGetRoutersHookWhenInitialRouterFails(t *testing.T) {
 	dbName := "dbname"
 
 	// Trigger read of routing table
-	_, err := router.Readers(context.Background(), nil, dbName, nil)
+	_, err := router.Readers(context.Background(), nil, dbName, nil, nil)
 	testutil.AssertStringContain(t, err.Error(), "Unable to retrieve routing table")
 
 	expected := []string{rootRouter}
@@ -265,7 +311,7 @@ func TestWritersFailAfterNRetries(t *testing.T) {
 	dbName := "dbname"
 
 	// Should trigger a lot of retries to get a writer until it finally fails
-	writers, err := router.Writers(context.Background(), nil, dbName, nil)
+	writers, err := router.Writers(context.Background(), nil, dbName, nil, nil)
 	if err == nil {
 		t.Error("Should have failed")
 	}
@@ -303,7 +349,7 @@ func TestWritersRetriesWhenNoWriters(t *testing.T) {
 
 	// Should trigger initial table read that contains no writers and a second table read
 	// that gets the writers
-	writers, err := router.Writers(context.Background(), nil, dbName, nil)
+	writers, err := router.Writers(context.Background(), nil, dbName, nil, nil)
 	if err != nil {
 		t.Errorf("Got error: %s", err)
 	}
@@ -341,7 +387,7 @@ func TestReadersRetriesWhenNoReaders(t *testing.T) {
 
 	// Should trigger initial table read that contains no readers and a second table read
 	// that gets the readers
-	readers, err := router.Readers(context.Background(), nil, dbName, nil)
+	readers, err := router.Readers(context.Background(), nil, dbName, nil, nil)
 	if err != nil {
 		t.Errorf("Got error: %s", err)
 	}
@@ -370,10 +416,10 @@ func TestCleanUp(t *testing.T) {
 	router.now = func() time.Time { return now }
 
 	ctx := context.Background()
-	if _, err := router.Readers(ctx, nil, "db1", nil); err != nil {
+	if _, err := router.Readers(ctx, nil, "db1", nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
-	if _, err := router.Readers(ctx, nil, "db2", nil); err != nil {
+	if _, err := router.Readers(ctx, nil, "db2", nil, nil); err != nil {
 		testutil.AssertNoError(t, err)
 	}
 
@@ -398,3 +444,425 @@ func TestCleanUp(t *testing.T) {
 		t.Fatal("Should have cleaned up")
 	}
 }
+
+func TestKnownServers(t *testing.T) {
+	tables := map[string]*db.RoutingTable{
+		"db1": {TimeToLive: 1, Routers: []string{"router1"}, Readers: []string{"reader1", "reader2"}, Writers: []string{"writer1"}},
+		"db2": {TimeToLive: 1, Routers: []string{"router1"}, Readers: []string{"reader2", "reader3"}, Writers: []string{"writer1"}},
+	}
+	database := "db1"
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			return &testutil.ConnFake{Table: tables[database]}, nil
+		},
+	}
+	router := New("router", nil, nil, pool, logger, "routerid")
+	ctx := context.Background()
+	_, err := router.Readers(ctx, nil, database, nil, nil)
+	testutil.AssertNoError(t, err)
+	database = "db2"
+	_, err = router.Readers(ctx, nil, database, nil, nil)
+	testutil.AssertNoError(t, err)
+
+	servers, err := router.KnownServers(ctx)
+
+	testutil.AssertNoError(t, err)
+	sort.Strings(servers)
+	testutil.AssertDeepEquals(t, servers, []string{"reader1", "reader2", "reader3", "router", "router1", "writer1"})
+}
+
+func TestRoutingPolicies(t *testing.T) {
+	tables := map[string]*db.RoutingTable{
+		"eu": {TimeToLive: 100, Readers: []string{"eu-reader"}, Writers: []string{"writer"}},
+		"us": {TimeToLive: 100, Readers: []string{"us-reader"}, Writers: []string{"writer"}},
+	}
+	numReads := 0
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			numReads++
+			return &testutil.ConnFake{Table: tables["eu"]}, nil
+		},
+	}
+	router := New("router", nil, map[string]string{"policy": "eu"}, pool, logger, "routerid")
+	usRouter := router.WithPolicy("us")
+	usPool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			numReads++
+			return &testutil.ConnFake{Table: tables["us"]}, nil
+		},
+	}
+	usRouter.pool = usPool
+	ctx := context.Background()
+
+	euReaders, err := router.Readers(ctx, nil, "db", nil, nil)
+	testutil.AssertNoError(t, err)
+	usReaders, err := usRouter.Readers(ctx, nil, "db", nil, nil)
+	testutil.AssertNoError(t, err)
+	_, err = router.WithPolicy("eu").Readers(ctx, nil, "db", nil, nil)
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertDeepEquals(t, euReaders, []string{"eu-reader"})
+	testutil.AssertDeepEquals(t, usReaders, []string{"us-reader"})
+	testutil.AssertIntEqual(t, numReads, 2)
+	testutil.AssertLen(t, router.dbRouters, 2)
+	testutil.AssertDeepEquals(t, usRouter.Context(), map[string]string{"policy": "us"})
+	testutil.AssertDeepEquals(t, router.Context(), map[string]string{"policy": "eu"})
+
+	testutil.AssertNoError(t, router.InvalidateWriter(ctx, "db", "writer"))
+	testutil.AssertLen(t, tables["eu"].Writers, 0)
+	testutil.AssertLen(t, tables["us"].Writers, 0)
+}
+
+func TestHomeDatabaseCache(outer *testing.T) {
+	ctx := context.Background()
+	homeDbs := map[string]string{"": "home", "jane": "janes"}
+	newRouter := func(numReads *int) *Router {
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				*numReads++
+				return &homeDbConnFake{homeDbs: homeDbs}, nil
+			},
+		}
+		return New("router", nil, nil, pool, logger, "routerid")
+	}
+	basicAuth := func(principal string) *db.ReAuthToken {
+		return &db.ReAuthToken{Token: map[string]interface{}{"scheme": "basic", "principal": principal, "credentials": "pass"}, FromSession: true}
+	}
+
+	outer.Run("caches home database per user and impersonated user", func(t *testing.T) {
+		numReads := 0
+		router := newRouter(&numReads)
+
+		for i := 0; i < 2; i++ {
+			name, err := router.GetNameOfDefaultDatabase(ctx, nil, "", nil, nil)
+			testutil.AssertNoError(t, err)
+			testutil.AssertStringEqual(t, name, "home")
+			name, err = router.GetNameOfDefaultDatabase(ctx, nil, "jane", nil, nil)
+			testutil.AssertNoError(t, err)
+			testutil.AssertStringEqual(t, name, "janes")
+			name, err = router.GetNameOfDefaultDatabase(ctx, nil, "", basicAuth("john"), nil)
+			testutil.AssertNoError(t, err)
+			testutil.AssertStringEqual(t, name, "home")
+		}
+
+		testutil.AssertIntEqual(t, numReads, 3)
+	})
+
+	outer.Run("does not cache home database of unidentified users", func(t *testing.T) {
+		numReads := 0
+		router := newRouter(&numReads)
+		bearerAuth := &db.ReAuthToken{Token: map[string]interface{}{"scheme": "bearer", "credentials": "token"}, FromSession: true}
+
+		for i := 0; i < 2; i++ {
+			_, err := router.GetNameOfDefaultDatabase(ctx, nil, "", bearerAuth, nil)
+			testutil.AssertNoError(t, err)
+		}
+
+		testutil.AssertIntEqual(t, numReads, 2)
+	})
+
+	outer.Run("caches home database of the driver user whatever its token", func(t *testing.T) {
+		numReads := 0
+		router := newRouter(&numReads)
+		driverTokens := []*db.ReAuthToken{
+			{Token: map[string]interface{}{"scheme": "bearer", "credentials": "token"}},
+			{Token: map[string]interface{}{"scheme": "none"}},
+			nil,
+		}
+
+		for _, driverToken := range driverTokens {
+			name, err := router.GetNameOfDefaultDatabase(ctx, nil, "", driverToken, nil)
+			testutil.AssertNoError(t, err)
+			testutil.AssertStringEqual(t, name, "home")
+		}
+
+		testutil.AssertIntEqual(t, numReads, 1)
+	})
+
+	outer.Run("expires home database with the routing table", func(t *testing.T) {
+		numReads := 0
+		router := newRouter(&numReads)
+		now := time.Now()
+		router.now = func() time.Time { return now }
+
+		_, err := router.GetNameOfDefaultDatabase(ctx, nil, "", nil, nil)
+		testutil.AssertNoError(t, err)
+		now = now.Add(2 * time.Second)
+		_, err = router.GetNameOfDefaultDatabase(ctx, nil, "", nil, nil)
+		testutil.AssertNoError(t, err)
+
+		testutil.AssertIntEqual(t, numReads, 2)
+	})
+
+	outer.Run("forgets home database when its routing table is invalidated", func(t *testing.T) {
+		numReads := 0
+		router := newRouter(&numReads)
+
+		_, err := router.GetNameOfDefaultDatabase(ctx, nil, "", nil, nil)
+		testutil.AssertNoError(t, err)
+		_, err = router.GetNameOfDefaultDatabase(ctx, nil, "jane", nil, nil)
+		testutil.AssertNoError(t, err)
+		testutil.AssertNoError(t, router.Invalidate(ctx, "home"))
+		_, err = router.GetNameOfDefaultDatabase(ctx, nil, "", nil, nil)
+		testutil.AssertNoError(t, err)
+		_, err = router.GetNameOfDefaultDatabase(ctx, nil, "jane", nil, nil)
+		testutil.AssertNoError(t, err)
+
+		testutil.AssertIntEqual(t, numReads, 3)
+	})
+
+	outer.Run("forgets home database that is not found", func(t *testing.T) {
+		numReads := 0
+		notFound := false
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				numReads++
+				if notFound {
+					return &testutil.ConnFake{Err: &neo4jdb.Neo4jError{Code: "Neo.ClientError.Database.DatabaseNotFound"}}, nil
+				}
+				return &homeDbConnFake{homeDbs: homeDbs}, nil
+			},
+		}
+		router := New("router", nil, nil, pool, logger, "routerid")
+
+		_, err := router.GetNameOfDefaultDatabase(ctx, nil, "", nil, nil)
+		testutil.AssertNoError(t, err)
+		router.dbRouters[routingKey{database: "home"}].dueUnix = 0
+		notFound = true
+		_, err = router.Readers(ctx, nil, "home", nil, nil)
+		testutil.AssertError(t, err)
+
+		testutil.AssertLen(t, router.homeDbs, 0)
+		testutil.AssertIntEqual(t, numReads, 2)
+	})
+}
+
+// Resolves the home database of the impersonated user
+type homeDbConnFake struct {
+	testutil.ConnFake
+	homeDbs map[string]string
+}
+
+func (c *homeDbConnFake) GetRoutingTable(_ context.Context, _ map[string]string, _ []string, _, impersonatedUser string) (*db.RoutingTable, error) {
+	return &db.RoutingTable{TimeToLive: 1, DatabaseName: c.homeDbs[impersonatedUser], Readers: []string{"reader"}, Writers: []string{"writer"}}, nil
+}
+
+func TestConcurrentTableReads(outer *testing.T) {
+	ctx := context.Background()
+
+	outer.Run("reads the routing table once for concurrent sessions", func(t *testing.T) {
+		release := make(chan struct{})
+		var numReads int32
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				atomic.AddInt32(&numReads, 1)
+				<-release
+				return &testutil.ConnFake{Table: &db.RoutingTable{TimeToLive: 100, Readers: []string{"reader"}}}, nil
+			},
+		}
+		router := New("router", nil, nil, pool, logger, "routerid")
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				readers, err := router.Readers(ctx, nil, "db", nil, nil)
+				testutil.AssertNoError(t, err)
+				testutil.AssertDeepEquals(t, readers, []string{"reader"})
+			}()
+		}
+		for atomic.LoadInt32(&numReads) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		close(release)
+		wg.Wait()
+
+		testutil.AssertIntEqual(t, int(atomic.LoadInt32(&numReads)), 1)
+	})
+
+	outer.Run("does not hold up other databases while reading a routing table", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				return &routingTableConnFake{getRoutingTable: func(database string) (*db.RoutingTable, error) {
+					if database == "slow" {
+						<-release
+					}
+					return &db.RoutingTable{TimeToLive: 100, Readers: []string{database + "-reader"}}, nil
+				}}, nil
+			},
+		}
+		router := New("router", nil, nil, pool, logger, "routerid")
+		go router.Readers(ctx, nil, "slow", nil, nil)
+
+		readers, err := router.Readers(ctx, nil, "fast", nil, nil)
+
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, readers, []string{"fast-reader"})
+	})
+
+	outer.Run("gives up waiting for a routing table read by another session", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		reading := make(chan struct{})
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				close(reading)
+				<-release
+				return &testutil.ConnFake{Table: &db.RoutingTable{TimeToLive: 100, Readers: []string{"reader"}}}, nil
+			},
+		}
+		router := New("router", nil, nil, pool, logger, "routerid")
+		go router.Readers(ctx, nil, "db", nil, nil)
+		<-reading
+		timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		_, err := router.Readers(timeoutCtx, nil, "db", nil, nil)
+
+		testutil.AssertErrorMessageContains(t, err, "routing table was not read in time")
+	})
+
+	outer.Run("refreshes the routing table in the background ahead of expiry", func(t *testing.T) {
+		refreshed := make(chan struct{})
+		numReads := 0
+		pool := &poolFake{
+			borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+				numReads++
+				if numReads > 1 {
+					defer close(refreshed)
+				}
+				reader := fmt.Sprintf("reader%d", numReads)
+				return &testutil.ConnFake{Table: &db.RoutingTable{TimeToLive: 10, Readers: []string{reader}}}, nil
+			},
+		}
+		router := New("router", nil, nil, pool, logger, "routerid")
+		now := time.Now()
+		router.now = func() time.Time { return now }
+
+		readers, err := router.Readers(ctx, nil, "db", nil, nil)
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, readers, []string{"reader1"})
+		now = now.Add(7 * time.Second)
+		readers, err = router.Readers(ctx, nil, "db", nil, nil)
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, readers, []string{"reader1"})
+		now = now.Add(2 * time.Second)
+		readers, err = router.Readers(ctx, nil, "db", nil, nil)
+		testutil.AssertNoError(t, err)
+		testutil.AssertDeepEquals(t, readers, []string{"reader1"})
+		<-refreshed
+		// The refreshed table is stored right after being read
+		for readers[0] != "reader2" {
+			time.Sleep(time.Millisecond)
+			readers, err = router.Readers(ctx, nil, "db", nil, nil)
+			testutil.AssertNoError(t, err)
+		}
+	})
+}
+
+// Returns the routing table provided by the hook for the requested database
+type routingTableConnFake struct {
+	testutil.ConnFake
+	getRoutingTable func(database string) (*db.RoutingTable, error)
+}
+
+func (c *routingTableConnFake) GetRoutingTable(_ context.Context, _ map[string]string, _ []string, database, _ string) (*db.RoutingTable, error) {
+	return c.getRoutingTable(database)
+}
+
+func TestRoutingTable(t *testing.T) {
+	table := &db.RoutingTable{TimeToLive: 100, Routers: []string{"router1"}, Readers: []string{"reader1", "reader2"}, Writers: []string{"writer1"}}
+	numReads := 0
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			numReads++
+			return &testutil.ConnFake{Table: table}, nil
+		},
+	}
+	router := New("router", nil, nil, pool, logger, "routerid")
+	now := time.Unix(1000, 0)
+	router.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	snapshot, expiresAt, err := router.RoutingTable(ctx, "db", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, snapshot, table)
+	testutil.AssertDeepEquals(t, expiresAt, time.Unix(1100, 0))
+
+	// Removing servers from the routing table must not change the copy
+	testutil.AssertNoError(t, router.InvalidateReader(ctx, "db", "reader1"))
+	testutil.AssertDeepEquals(t, snapshot.Readers, []string{"reader1", "reader2"})
+	snapshot, _, err = router.RoutingTable(ctx, "db", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, snapshot.Readers, []string{"reader2"})
+	testutil.AssertIntEqual(t, numReads, 1)
+
+	now = now.Add(10 * time.Second)
+	_, expiresAt, err = router.RefreshRoutingTable(ctx, "db", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertDeepEquals(t, expiresAt, time.Unix(1110, 0))
+	testutil.AssertIntEqual(t, numReads, 2)
+}
+
+func TestTableListener(t *testing.T) {
+	table := &db.RoutingTable{TimeToLive: 10, Routers: []string{"router1"}, Readers: []string{"reader1", "reader2"}, Writers: []string{"writer1"}}
+	pool := &poolFake{
+		borrow: func(names []string, cancel context.CancelFunc, _ log.BoltLogger) (db.Connection, error) {
+			return &testutil.ConnFake{Table: table}, nil
+		},
+	}
+	router := New("router", nil, nil, pool, logger, "routerid")
+	now := time.Unix(1000, 0)
+	router.now = func() time.Time { return now }
+	ctx := context.Background()
+	var changes []TableChange
+	router.SetTableListener(func(change TableChange) {
+		// The listener is called without holding the lock
+		_, err := router.KnownServers(ctx)
+		testutil.AssertNoError(t, err)
+		changes = append(changes, change)
+	})
+
+	_, err := router.Readers(ctx, nil, "db", nil, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, changes, 1)
+	testutil.AssertStringEqual(t, changes[0].Reason, TableUpdated)
+	testutil.AssertStringEqual(t, changes[0].Database, "db")
+	testutil.AssertNil(t, changes[0].Before)
+	testutil.AssertDeepEquals(t, changes[0].After, &TableSnapshot{Table: table, ExpiresAt: time.Unix(1010, 0)})
+	testutil.AssertDeepEquals(t, changes[0].Writers, ServersDiff{Added: []string{"writer1"}})
+
+	// Refreshes without server changes are not notified
+	testutil.AssertNoError(t, router.Invalidate(ctx, "db"))
+	_, err = router.Readers(ctx, nil, "db", nil, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, changes, 1)
+
+	table = &db.RoutingTable{TimeToLive: 10, Routers: []string{"router1"}, Readers: []string{"reader1", "reader2"}, Writers: []string{"writer2"}}
+	testutil.AssertNoError(t, router.Invalidate(ctx, "db"))
+	_, err = router.Readers(ctx, nil, "db", nil, nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertLen(t, changes, 2)
+	testutil.AssertStringEqual(t, changes[1].Reason, TableUpdated)
+	testutil.AssertDeepEquals(t, changes[1].Before.Table.Writers, []string{"writer1"})
+	testutil.AssertDeepEquals(t, changes[1].After.Table.Writers, []string{"writer2"})
+	testutil.AssertDeepEquals(t, changes[1].Writers, ServersDiff{Added: []string{"writer2"}, Removed: []string{"writer1"}})
+	testutil.AssertDeepEquals(t, changes[1].Readers, ServersDiff{})
+
+	testutil.AssertNoError(t, router.InvalidateReader(ctx, "db", "reader1"))
+	testutil.AssertLen(t, changes, 3)
+	testutil.AssertStringEqual(t, changes[2].Reason, ServerRemoved)
+	testutil.AssertDeepEquals(t, changes[2].Before.Table.Readers, []string{"reader1", "reader2"})
+	testutil.AssertDeepEquals(t, changes[2].After.Table.Readers, []string{"reader2"})
+	testutil.AssertDeepEquals(t, changes[2].Readers, ServersDiff{Removed: []string{"reader1"}})
+
+	now = now.Add(time.Minute)
+	testutil.AssertNoError(t, router.CleanUp(ctx))
+	testutil.AssertLen(t, changes, 4)
+	testutil.AssertStringEqual(t, changes[3].Reason, TableRemoved)
+	testutil.AssertNil(t, changes[3].After)
+	testutil.AssertDeepEquals(t, changes[3].Writers, ServersDiff{Removed: []string{"writer2"}})
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package structs maps Go struct fields to Cypher map keys.
+package structs
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TagName is the name of the struct tag customizing how a field maps to a Cypher map key.
+// The tag value is the key, optionally followed by ",omitempty". A "-" tag value skips the field.
+const TagName = "neo4j"
+
+// Field describes an exported struct field mapped to a Cypher map key
+type Field struct {
+	// Name is the Cypher map key
+	Name string
+	// Index is the index sequence of the field, as expected by reflect.Value.FieldByIndex
+	Index []int
+	// OmitEmpty is true when the field should not be encoded if it holds an empty value
+	OmitEmpty bool
+}
+
+var cache sync.Map // reflect.Type -> []Field
+
+// Fields returns the mapped fields of the given struct type.
+// Fields of embedded structs without tag are promoted, as encoding/json does.
+func Fields(t reflect.Type) []Field {
+	if fields, ok := cache.Load(t); ok {
+		return fields.([]Field)
+	}
+	fields := collectFields(t, nil)
+	cache.Store(t, fields)
+	return fields
+}
+
+func collectFields(t reflect.Type, parentIndex []int) []Field {
+	var fields []Field
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		tag, hasTag := structField.Tag.Lookup(TagName)
+		if tag == "-" {
+			continue
+		}
+		index := make([]int, len(parentIndex)+1)
+		copy(index, parentIndex)
+		index[len(parentIndex)] = i
+		if structField.Anonymous && !hasTag && structField.Type.Kind() == reflect.Struct {
+			fields = append(fields, collectFields(structField.Type, index)...)
+			continue
+		}
+		if !structField.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = structField.Name
+		}
+		fields = append(fields, Field{
+			Name:      name,
+			Index:     index,
+			OmitEmpty: options == "omitempty",
+		})
+	}
+	return fields
+}
@@ -23,6 +23,7 @@ import (
 	"context"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"reflect"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
@@ -35,11 +36,12 @@ type Next struct {
 }
 
 type RecordedTx struct {
-	Origin    string
-	Mode      idb.AccessMode
-	Bookmarks []string
-	Timeout   time.Duration
-	Meta      map[string]interface{}
+	Origin       string
+	Mode         idb.AccessMode
+	Bookmarks    []string
+	Timeout      time.Duration
+	Meta         map[string]interface{}
+	TelemetryAPI idb.TelemetryAPI
 }
 
 type ConnFake struct {
@@ -48,6 +50,7 @@ type ConnFake struct {
 	Alive              bool
 	Birth              time.Time
 	Table              *idb.RoutingTable
+	TableHook          func()
 	Err                error
 	Id                 int
 	TxBeginErr         error
@@ -71,9 +74,13 @@ type ConnFake struct {
 	Idle               time.Time
 	ServerVersionValue string
 	ForceResetHook     func()
+	Auth               map[string]interface{}
+	ReAuthErr          error
+	ReAuthHook         func(*idb.ReAuthToken)
+	RunLatency         time.Duration
 }
 
-func (c *ConnFake) Connect(context.Context, int, map[string]interface{}, string, map[string]string) error {
+func (c *ConnFake) Connect(context.Context, int, map[string]interface{}, string, map[string]string, idb.NotificationConfig) error {
 	return nil
 }
 
@@ -99,6 +106,12 @@ func (c *ConnFake) ForceReset(context.Context) {
 func (c *ConnFake) Close(ctx context.Context) {
 }
 
+func (c *ConnFake) TakeRunLatency() time.Duration {
+	latency := c.RunLatency
+	c.RunLatency = 0
+	return latency
+}
+
 func (c *ConnFake) Birthdate() time.Time {
 	return c.Birth
 }
@@ -130,6 +143,9 @@ func (c *ConnFake) Consume(context.Context, idb.StreamHandle) (*db.Summary, erro
 }
 
 func (c *ConnFake) GetRoutingTable(_ context.Context, _ map[string]string, _ []string, database, _ string) (*idb.RoutingTable, error) {
+	if c.TableHook != nil {
+		c.TableHook()
+	}
 	if c.Table != nil {
 		c.Table.DatabaseName = database
 	}
@@ -137,7 +153,7 @@ func (c *ConnFake) GetRoutingTable(_ context.Context, _ map[string]string, _ []s
 }
 
 func (c *ConnFake) TxBegin(_ context.Context, txConfig idb.TxConfig) (idb.TxHandle, error) {
-	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "TxBegin", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta})
+	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "TxBegin", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta, TelemetryAPI: txConfig.TelemetryAPI})
 	return c.TxBeginHandle, c.TxBeginErr
 }
 
@@ -154,7 +170,7 @@ func (c *ConnFake) TxCommit(context.Context, idb.TxHandle) error {
 
 func (c *ConnFake) Run(_ context.Context, _ idb.Command, txConfig idb.TxConfig) (idb.StreamHandle, error) {
 
-	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "Run", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta})
+	c.RecordedTxs = append(c.RecordedTxs, RecordedTx{Origin: "Run", Mode: txConfig.Mode, Bookmarks: txConfig.Bookmarks, Timeout: txConfig.Timeout, Meta: txConfig.Meta, TelemetryAPI: txConfig.TelemetryAPI})
 	return c.RunStream, c.RunErr
 }
 
@@ -189,3 +205,18 @@ func (c *ConnFake) SetBoltLogger(log.BoltLogger) {
 func (c *ConnFake) Version() db.ProtocolVersion {
 	return c.ConnectionVersion
 }
+
+func (c *ConnFake) IsAuthenticatedWith(auth map[string]interface{}) bool {
+	return reflect.DeepEqual(c.Auth, auth)
+}
+
+func (c *ConnFake) ReAuth(_ context.Context, auth *idb.ReAuthToken) error {
+	if c.ReAuthHook != nil {
+		c.ReAuthHook(auth)
+	}
+	if c.ReAuthErr != nil {
+		return c.ReAuthErr
+	}
+	c.Auth = auth.Token
+	return nil
+}
@@ -32,9 +32,14 @@ type PoolFake struct {
 	ReturnHook  func()
 	CleanUpHook func()
 	BorrowHook  func() (db.Connection, error)
+	BorrowAuth  *db.ReAuthToken // Authentication token of the last borrow request
+	// Liveness check threshold of the last borrow request
+	BorrowLivenessCheckThreshold time.Duration
 }
 
-func (p *PoolFake) Borrow(context.Context, []string, bool, log.BoltLogger, time.Duration) (db.Connection, error) {
+func (p *PoolFake) Borrow(_ context.Context, _ []string, _ bool, _ log.BoltLogger, livenessCheckThreshold time.Duration, auth *db.ReAuthToken) (db.Connection, error) {
+	p.BorrowAuth = auth
+	p.BorrowLivenessCheckThreshold = livenessCheckThreshold
 	if p.BorrowHook != nil && (p.BorrowConn != nil || p.BorrowErr != nil) {
 		panic("either use the hook or the desired return values, but not both")
 	}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package testutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
+)
+
+// TracerFake records the spans in memory, in the order they are started
+type TracerFake struct {
+	mut   sync.Mutex
+	spans []*SpanFake
+}
+
+type SpanFake struct {
+	Operation  tracing.Operation
+	Attributes tracing.Attributes
+	// Parent is the span attached to the context the span was started with, if any
+	Parent *SpanFake
+	Ended  bool
+	Err    error
+}
+
+type spanFakeKey struct{}
+
+func (t *TracerFake) StartSpan(ctx context.Context, operation tracing.Operation, attributes tracing.Attributes) (context.Context, tracing.Span) {
+	parent, _ := ctx.Value(spanFakeKey{}).(*SpanFake)
+	span := &SpanFake{Operation: operation, Attributes: attributes, Parent: parent}
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanFakeKey{}, span), span
+}
+
+func (t *TracerFake) Spans() []*SpanFake {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	return append([]*SpanFake(nil), t.spans...)
+}
+
+func (t *TracerFake) Operations() []tracing.Operation {
+	spans := t.Spans()
+	operations := make([]tracing.Operation, len(spans))
+	for i, span := range spans {
+		operations[i] = span.Operation
+	}
+	return operations
+}
+
+func (s *SpanFake) End(err error) {
+	s.Ended = true
+	s.Err = err
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
+)
+
+// ServerStats describes a server a connection can be borrowed from, see LoadBalancingStrategy
+type ServerStats struct {
+	// Address is the address of the server
+	Address string
+	// InUse is the number of connections to the server currently borrowed from the pool
+	InUse int
+	// Idle is the number of connections to the server currently idle in the pool
+	Idle int
+	// Latency is the exponentially weighted moving average of the time between sending a query to the server
+	// and receiving its first response. It is zero until a query has been answered by the server.
+	// Only the last query run on a connection before it goes back to the pool is sampled.
+	Latency time.Duration
+	// RecentlyFailed is true when connecting to the server has failed recently
+	RecentlyFailed bool
+}
+
+// LoadBalancingStrategy selects the server to borrow a connection from, for instance among the readers or the
+// writers of a database. The other servers are tried next when connecting to the selected server fails.
+//
+// Implementations must be safe for concurrent use.
+type LoadBalancingStrategy interface {
+	// SelectServer returns the address of one of the provided servers, there is always at least one server
+	SelectServer(servers []ServerStats) string
+}
+
+// LoadBalancingStrategyFunc adapts a function to the LoadBalancingStrategy interface
+type LoadBalancingStrategyFunc func(servers []ServerStats) string
+
+func (f LoadBalancingStrategyFunc) SelectServer(servers []ServerStats) string {
+	return f(servers)
+}
+
+// LeastConnectedStrategy selects the server with the fewest connections in use, preferring servers with idle
+// connections. Ties are broken in a round-robin fashion and servers that recently failed are avoided.
+func LeastConnectedStrategy() LoadBalancingStrategy {
+	return &minimumStrategy{less: func(x, y ServerStats) bool {
+		if x.InUse != y.InUse {
+			return x.InUse < y.InUse
+		}
+		return x.Idle > 0 && y.Idle == 0
+	}}
+}
+
+// RoundRobinStrategy selects servers in turn, servers that recently failed are avoided.
+func RoundRobinStrategy() LoadBalancingStrategy {
+	return &minimumStrategy{less: func(ServerStats, ServerStats) bool {
+		return false
+	}}
+}
+
+// LatencyWeightedStrategy selects the server with the lowest latency, weighted by its number of connections in use.
+// Servers with no measured latency yet are selected first, so that their latency gets measured.
+// Ties are broken in a round-robin fashion and servers that recently failed are avoided.
+func LatencyWeightedStrategy() LoadBalancingStrategy {
+	return &minimumStrategy{less: func(x, y ServerStats) bool {
+		return weightedLatency(x) < weightedLatency(y)
+	}}
+}
+
+func weightedLatency(server ServerStats) time.Duration {
+	return server.Latency * time.Duration(server.InUse+1)
+}
+
+// Selects the minimum server according to less, starting from a rotating position to break ties in turn
+type minimumStrategy struct {
+	next uint32
+	less func(x, y ServerStats) bool
+}
+
+func (s *minimumStrategy) SelectServer(servers []ServerStats) string {
+	start := int(atomic.AddUint32(&s.next, 1) % uint32(len(servers)))
+	selected := -1
+	for i := range servers {
+		candidate := (start + i) % len(servers)
+		if selected == -1 || s.isBetter(servers[candidate], servers[selected]) {
+			selected = candidate
+		}
+	}
+	return servers[selected].Address
+}
+
+func (s *minimumStrategy) isBetter(x, y ServerStats) bool {
+	if x.RecentlyFailed != y.RecentlyFailed {
+		return !x.RecentlyFailed
+	}
+	return s.less(x, y)
+}
+
+func toSelectServer(strategy LoadBalancingStrategy) pool.SelectServer {
+	if strategy == nil {
+		return nil
+	}
+	return func(servers []pool.ServerStats) string {
+		stats := make([]ServerStats, len(servers))
+		for i, server := range servers {
+			stats[i] = ServerStats{
+				Address:        server.Address,
+				InUse:          server.InUse,
+				Idle:           server.Idle,
+				Latency:        server.Latency,
+				RecentlyFailed: server.RecentlyFailed,
+			}
+		}
+		return strategy.SelectServer(stats)
+	}
+}
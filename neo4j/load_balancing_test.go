@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestLoadBalancingStrategies(outer *testing.T) {
+	selectTimes := func(strategy LoadBalancingStrategy, servers []ServerStats, times int) []string {
+		selected := make([]string, times)
+		for i := range selected {
+			selected[i] = strategy.SelectServer(servers)
+		}
+		return selected
+	}
+
+	outer.Run("least connected selects the server with the fewest connections in use", func(t *testing.T) {
+		servers := []ServerStats{
+			{Address: "a", InUse: 2, Idle: 1},
+			{Address: "b", InUse: 1},
+			{Address: "c", InUse: 1, Idle: 1},
+		}
+
+		selected := selectTimes(LeastConnectedStrategy(), servers, 3)
+
+		AssertDeepEquals(t, selected, []string{"c", "c", "c"})
+	})
+
+	outer.Run("least connected breaks ties in turn", func(t *testing.T) {
+		servers := []ServerStats{{Address: "a"}, {Address: "b"}}
+
+		selected := selectTimes(LeastConnectedStrategy(), servers, 4)
+
+		AssertDeepEquals(t, selected, []string{"b", "a", "b", "a"})
+	})
+
+	outer.Run("round robin selects servers in turn", func(t *testing.T) {
+		servers := []ServerStats{{Address: "a", InUse: 5}, {Address: "b"}, {Address: "c"}}
+
+		selected := selectTimes(RoundRobinStrategy(), servers, 4)
+
+		AssertDeepEquals(t, selected, []string{"b", "c", "a", "b"})
+	})
+
+	outer.Run("strategies avoid servers that recently failed", func(t *testing.T) {
+		servers := []ServerStats{{Address: "a", RecentlyFailed: true}, {Address: "b", InUse: 10, Latency: time.Second}}
+
+		for _, strategy := range []LoadBalancingStrategy{LeastConnectedStrategy(), RoundRobinStrategy(), LatencyWeightedStrategy()} {
+			AssertDeepEquals(t, selectTimes(strategy, servers, 2), []string{"b", "b"})
+		}
+	})
+
+	outer.Run("latency weighted selects the server with the lowest latency per connection in use", func(t *testing.T) {
+		servers := []ServerStats{
+			{Address: "a", Latency: 10 * time.Millisecond},
+			{Address: "b", Latency: 2 * time.Millisecond, InUse: 9},
+			{Address: "c", Latency: 6 * time.Millisecond, InUse: 1},
+		}
+
+		selected := selectTimes(LatencyWeightedStrategy(), servers, 2)
+
+		AssertDeepEquals(t, selected, []string{"a", "a"})
+	})
+
+	outer.Run("latency weighted selects servers without measured latency first", func(t *testing.T) {
+		servers := []ServerStats{{Address: "a", Latency: time.Millisecond}, {Address: "b"}}
+
+		selected := selectTimes(LatencyWeightedStrategy(), servers, 2)
+
+		AssertDeepEquals(t, selected, []string{"b", "b"})
+	})
+
+	outer.Run("converts pool server stats", func(t *testing.T) {
+		var received []ServerStats
+		selectServer := toSelectServer(LoadBalancingStrategyFunc(func(servers []ServerStats) string {
+			received = servers
+			return "b"
+		}))
+
+		selected := selectServer([]pool.ServerStats{{Address: "a", InUse: 1, Idle: 2, Latency: time.Second, RecentlyFailed: true}, {Address: "b"}})
+
+		AssertStringEqual(t, selected, "b")
+		AssertDeepEquals(t, received, []ServerStats{{Address: "a", InUse: 1, Idle: 2, Latency: time.Second, RecentlyFailed: true}, {Address: "b"}})
+	})
+}
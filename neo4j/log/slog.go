@@ -0,0 +1,70 @@
+//go:build go1.21
+
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Slog returns a Logger that forwards the driver log entries to the given log/slog handler.
+// Component name, id, connection id, server and database are forwarded as attributes, see Structured.
+//
+// Entries below minLevel are discarded before reaching the handler, in addition to the
+// filtering the handler applies itself. A nil minLevel leaves the filtering to the handler.
+func Slog(handler slog.Handler, minLevel slog.Leveler) Logger {
+	return Structured(&slogLogger{handler: handler, minLevel: minLevel})
+}
+
+type slogLogger struct {
+	handler  slog.Handler
+	minLevel slog.Leveler
+}
+
+func (l *slogLogger) Enabled(level Level) bool {
+	slogLevel := toSlogLevel(level)
+	if l.minLevel != nil && slogLevel < l.minLevel.Level() {
+		return false
+	}
+	return l.handler.Enabled(context.Background(), slogLevel)
+}
+
+func (l *slogLogger) Log(level Level, msg string, fields []Field) {
+	record := slog.NewRecord(time.Now(), toSlogLevel(level), msg, 0)
+	for _, field := range fields {
+		record.AddAttrs(slog.Any(field.Key, field.Value))
+	}
+	_ = l.handler.Handle(context.Background(), record)
+}
+
+func toSlogLevel(level Level) slog.Level {
+	switch level {
+	case ERROR:
+		return slog.LevelError
+	case WARNING:
+		return slog.LevelWarn
+	case INFO:
+		return slog.LevelInfo
+	}
+	return slog.LevelDebug
+}
@@ -0,0 +1,138 @@
+//go:build go1.21
+
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSlog(outer *testing.T) {
+	newLogger := func(minLevel slog.Leveler) (Logger, *bytes.Buffer) {
+		buffer := &bytes.Buffer{}
+		handler := slog.NewJSONHandler(buffer, &slog.HandlerOptions{
+			Level: slog.LevelDebug,
+			ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+				if attr.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return attr
+			},
+		})
+		return Slog(handler, minLevel), buffer
+	}
+
+	entries := func(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+		t.Helper()
+		var result []map[string]interface{}
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			entry := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(line), &entry); err != nil {
+				t.Fatalf("could not parse log entry %q: %v", line, err)
+			}
+			result = append(result, entry)
+		}
+		return result
+	}
+
+	assertEntries := func(t *testing.T, actual, expected []map[string]interface{}) {
+		t.Helper()
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("expected entries %v but got %v", expected, actual)
+		}
+	}
+
+	outer.Run("forwards component name and id as attributes", func(t *testing.T) {
+		logger, buffer := newLogger(nil)
+
+		logger.Infof(Router, "7", "Created {context: %v}", "ctx")
+
+		assertEntries(t, entries(t, buffer), []map[string]interface{}{
+			{"level": "INFO", "msg": "Created {context: ctx}", "component": "router", "id": "7"},
+		})
+	})
+
+	outer.Run("splits connection identities", func(t *testing.T) {
+		logger, buffer := newLogger(nil)
+
+		logger.Debugf(Bolt5, "bolt-123@localhost:7687", "Connected")
+
+		assertEntries(t, entries(t, buffer), []map[string]interface{}{{
+			"level":         "DEBUG",
+			"msg":           "Connected",
+			"component":     "bolt5",
+			"id":            "bolt-123@localhost:7687",
+			"connection_id": "bolt-123",
+			"server":        "localhost:7687",
+		}})
+	})
+
+	outer.Run("attaches database", func(t *testing.T) {
+		logger, buffer := newLogger(nil)
+
+		WithDatabase(WithDatabase(logger, "db1"), "db2").Warnf(Session, "1", "Careful")
+
+		assertEntries(t, entries(t, buffer), []map[string]interface{}{
+			{"level": "WARN", "msg": "Careful", "component": "session", "id": "1", "database": "db2"},
+		})
+	})
+
+	outer.Run("logs errors", func(t *testing.T) {
+		logger, buffer := newLogger(nil)
+
+		logger.Error(Pool, "2", errors.New("oopsie"))
+
+		assertEntries(t, entries(t, buffer), []map[string]interface{}{
+			{"level": "ERROR", "msg": "oopsie", "component": "pool", "id": "2", "error": "oopsie"},
+		})
+	})
+
+	outer.Run("filters levels", func(t *testing.T) {
+		logger, buffer := newLogger(slog.LevelWarn)
+
+		logger.Debugf(Driver, "1", "debug")
+		logger.Infof(Driver, "1", "info")
+		logger.Warnf(Driver, "1", "warn")
+		logger.Error(Driver, "1", errors.New("error"))
+
+		actual := entries(t, buffer)
+		if len(actual) != 2 || actual[0]["msg"] != "warn" || actual[1]["msg"] != "error" {
+			t.Errorf("expected only warn and error entries but got %v", actual)
+		}
+	})
+
+	outer.Run("does not attach database to unstructured loggers", func(t *testing.T) {
+		logger := &Console{}
+
+		if WithDatabase(logger, "db1") != Logger(logger) {
+			t.Errorf("expected the logger to be returned as is")
+		}
+	})
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package log
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of a log entry, levels are ordered from the most to the least severe
+type Level int
+
+const (
+	ERROR   Level = 1
+	WARNING Level = 2
+	INFO    Level = 3
+	DEBUG   Level = 4
+)
+
+func (l Level) String() string {
+	switch l {
+	case ERROR:
+		return "ERROR"
+	case WARNING:
+		return "WARN"
+	case INFO:
+		return "INFO"
+	case DEBUG:
+		return "DEBUG"
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// Keys of the fields attached to structured log entries
+const (
+	// ComponentKey is the key of the name of the logging component, such as "router" or "bolt5"
+	ComponentKey = "component"
+	// IdKey is the key of the identity of the logging component
+	IdKey = "id"
+	// ConnectionIdKey is the key of the identity the server assigned to a connection, such as "bolt-123"
+	ConnectionIdKey = "connection_id"
+	// ServerKey is the key of the address of the server a connection is established with
+	ServerKey = "server"
+	// DatabaseKey is the key of the name of the database the logged operation targets
+	DatabaseKey = "database"
+	// ErrorKey is the key of the error reported by Logger.Error
+	ErrorKey = "error"
+)
+
+// Field is a key/value pair attached to a structured log entry
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// StructuredLogger receives log entries as a message along with key/value fields instead of
+// printf-style messages. Use Structured to turn it into a Logger the driver can be configured with.
+type StructuredLogger interface {
+	// Enabled reports whether entries of the given level are logged.
+	// Entries of disabled levels are neither formatted nor passed to Log.
+	Enabled(level Level) bool
+	// Log logs an entry, fields always start with the component name and id.
+	Log(level Level, msg string, fields []Field)
+}
+
+// Structured returns a Logger that forwards the entries to the given StructuredLogger.
+// The component name and id passed to the Logger functions are broken down into fields:
+// connection identities of the form "bolt-123@192.168.0.1:7687" are split into the
+// connection id and the server address.
+func Structured(logger StructuredLogger) Logger {
+	return &structuredLogger{delegate: logger}
+}
+
+// WithDatabase returns a Logger that attaches the database name to the entries.
+// This is only effective for loggers created by Structured, other loggers are returned as is.
+func WithDatabase(logger Logger, database string) Logger {
+	structured, ok := logger.(*structuredLogger)
+	if !ok || database == "" {
+		return logger
+	}
+	return &structuredLogger{delegate: structured.delegate, database: database}
+}
+
+type structuredLogger struct {
+	delegate StructuredLogger
+	database string
+}
+
+func (l *structuredLogger) Error(name, id string, err error) {
+	if !l.delegate.Enabled(ERROR) {
+		return
+	}
+	l.delegate.Log(ERROR, err.Error(), append(l.fields(name, id), Field{Key: ErrorKey, Value: err}))
+}
+
+func (l *structuredLogger) Warnf(name, id string, msg string, args ...interface{}) {
+	l.logf(WARNING, name, id, msg, args)
+}
+
+func (l *structuredLogger) Infof(name, id string, msg string, args ...interface{}) {
+	l.logf(INFO, name, id, msg, args)
+}
+
+func (l *structuredLogger) Debugf(name, id string, msg string, args ...interface{}) {
+	l.logf(DEBUG, name, id, msg, args)
+}
+
+func (l *structuredLogger) logf(level Level, name, id string, msg string, args []interface{}) {
+	if !l.delegate.Enabled(level) {
+		return
+	}
+	l.delegate.Log(level, fmt.Sprintf(msg, args...), l.fields(name, id))
+}
+
+func (l *structuredLogger) fields(name, id string) []Field {
+	fields := make([]Field, 0, 5)
+	fields = append(fields, Field{Key: ComponentKey, Value: name}, Field{Key: IdKey, Value: id})
+	if connectionId, server, found := strings.Cut(id, "@"); found {
+		fields = append(fields,
+			Field{Key: ConnectionIdKey, Value: connectionId},
+			Field{Key: ServerKey, Value: server})
+	}
+	if l.database != "" {
+		fields = append(fields, Field{Key: DatabaseKey, Value: l.database})
+	}
+	return fields
+}
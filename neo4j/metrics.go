@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
+)
+
+// DriverMetrics is a point-in-time snapshot of the driver metrics
+type DriverMetrics struct {
+	// ConnectionPools contains the connection pool metrics, keyed by server address.
+	// Servers the driver stopped connecting to are kept, so counters never go backwards.
+	ConnectionPools map[string]ConnectionPoolMetrics
+}
+
+// ConnectionPoolMetrics contains the connection pool metrics of a single server
+type ConnectionPoolMetrics struct {
+	// Idle is the number of connections currently idle in the pool
+	Idle int64
+	// InUse is the number of connections currently borrowed from the pool
+	InUse int64
+	// Creating is the number of connections currently being established
+	Creating int64
+	// Created is the total number of connections successfully established
+	Created int64
+	// Closed is the total number of connections closed by the pool
+	Closed int64
+	// FailedToCreate is the total number of connections that could not be established
+	FailedToCreate int64
+	// Acquired is the total number of connections successfully acquired from the pool
+	Acquired int64
+	// AcquisitionTimeouts is the total number of acquisitions that timed out waiting for a connection.
+	// An acquisition among several servers that times out is counted once, for the server it preferred.
+	AcquisitionTimeouts int64
+	// TotalAcquisitionTime is the total time spent acquiring connections, including time spent waiting
+	// for a connection to become available and time spent establishing new connections.
+	// Divide it by Acquired to get the average acquisition time.
+	TotalAcquisitionTime time.Duration
+	// FailedLivenessChecks is the total number of idle connections closed because they failed the liveness check,
+	// see Config.ConnectionLivenessCheckTimeout
+	FailedLivenessChecks int64
+}
+
+func newDriverMetrics(serverMetrics map[string]pool.ServerMetrics) DriverMetrics {
+	connectionPools := make(map[string]ConnectionPoolMetrics, len(serverMetrics))
+	for address, metrics := range serverMetrics {
+		connectionPools[address] = ConnectionPoolMetrics{
+			Idle:                 metrics.Idle,
+			InUse:                metrics.InUse,
+			Creating:             metrics.Creating,
+			Created:              metrics.Created,
+			Closed:               metrics.Closed,
+			FailedToCreate:       metrics.FailedToCreate,
+			Acquired:             metrics.Acquired,
+			AcquisitionTimeouts:  metrics.AcquisitionTimeouts,
+			TotalAcquisitionTime: metrics.AcquisitionTime,
+			FailedLivenessChecks: metrics.FailedLivenessChecks,
+		}
+	}
+	return DriverMetrics{ConnectionPools: connectionPools}
+}
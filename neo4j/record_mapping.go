@@ -0,0 +1,212 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/structs"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// RecordAs maps the record to a new instance of T, which must be a struct type.
+// It can be passed as mapper to CollectTWithContext and SingleTWithContext:
+//
+//	people, err := neo4j.CollectTWithContext(ctx, result, neo4j.RecordAs[Person])
+//
+// Each exported field of T is populated with the record value whose key is given by the field's
+// `neo4j:"key"` tag, or by the field name when the field is not tagged. Fields tagged `neo4j:"-"`
+// are ignored and the fields of untagged embedded structs are populated as if they were fields of T.
+// An error is returned when a key is missing from the record, or when its value cannot be decoded
+// with Config.LazyRecordDecoding, see Record.Err.
+//
+// Values are decoded as follows:
+//   - null values set fields to their zero value, use pointer fields to tell nulls apart
+//   - integers and floats are converted to any numeric field type able to hold them
+//   - lists are decoded element by element into slice fields
+//   - maps, as well as the properties of dbtype.Node and dbtype.Relationship values, are decoded
+//     into map or struct fields, properties missing from the value leave struct fields untouched
+//   - dbtype.Date, dbtype.LocalTime, dbtype.LocalDateTime and dbtype.Time are decoded into time.Time fields
+//   - any value can be decoded into a field whose type it is assignable to, such as interface{}
+//     or its own type
+func RecordAs[T any](record *Record) (T, error) {
+	var result T
+	if record == nil {
+		return result, &UsageError{Message: "Cannot map nil record"}
+	}
+	target := reflect.ValueOf(&result).Elem()
+	if target.Kind() != reflect.Struct {
+		return result, &UsageError{Message: fmt.Sprintf("Expected struct type to map record to, not %s", target.Type())}
+	}
+	for _, field := range structs.Fields(target.Type()) {
+		value, found := record.Get(field.Name)
+		if !found {
+			return *new(T), &UsageError{Message: fmt.Sprintf("Cannot map record to %s: key %q not found", target.Type(), field.Name)}
+		}
+		if err := record.Err(); err != nil {
+			return *new(T), fmt.Errorf("cannot map record to %s: key %q: %w", target.Type(), field.Name, err)
+		}
+		if err := decodeValue(value, target.FieldByIndex(field.Index)); err != nil {
+			return *new(T), &UsageError{Message: fmt.Sprintf("Cannot map record to %s: key %q: %s", target.Type(), field.Name, err)}
+		}
+	}
+	return result, nil
+}
+
+func decodeValue(value interface{}, target reflect.Value) error {
+	if value == nil {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	if target.Kind() == reflect.Pointer {
+		elem := reflect.New(target.Type().Elem())
+		if err := decodeValue(value, elem.Elem()); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	}
+	source := reflect.ValueOf(value)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return nil
+	}
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, ok := value.(int64); ok && !target.OverflowInt(i) {
+			target.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i, ok := value.(int64); ok && i >= 0 && !target.OverflowUint(uint64(i)) {
+			target.SetUint(uint64(i))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch v := value.(type) {
+		case float64:
+			if !target.OverflowFloat(v) {
+				target.SetFloat(v)
+				return nil
+			}
+		case int64:
+			target.SetFloat(float64(v))
+			return nil
+		}
+	case reflect.String:
+		if s, ok := value.(string); ok {
+			target.SetString(s)
+			return nil
+		}
+	case reflect.Bool:
+		if b, ok := value.(bool); ok {
+			target.SetBool(b)
+			return nil
+		}
+	case reflect.Slice:
+		if list, ok := value.([]interface{}); ok {
+			return decodeList(list, target)
+		}
+	case reflect.Map:
+		if properties, ok := propertiesOf(value); ok && target.Type().Key().Kind() == reflect.String {
+			return decodeMap(properties, target)
+		}
+	case reflect.Struct:
+		if target.Type() == timeType {
+			if t, ok := temporalTime(value); ok {
+				target.Set(reflect.ValueOf(t))
+				return nil
+			}
+			break
+		}
+		if properties, ok := propertiesOf(value); ok {
+			return decodeStruct(properties, target)
+		}
+	}
+	return fmt.Errorf("cannot decode %T into %s", value, target.Type())
+}
+
+func decodeList(list []interface{}, target reflect.Value) error {
+	slice := reflect.MakeSlice(target.Type(), len(list), len(list))
+	for i, item := range list {
+		if err := decodeValue(item, slice.Index(i)); err != nil {
+			return fmt.Errorf("index %d: %w", i, err)
+		}
+	}
+	target.Set(slice)
+	return nil
+}
+
+func decodeMap(properties map[string]interface{}, target reflect.Value) error {
+	mapType := target.Type()
+	result := reflect.MakeMapWithSize(mapType, len(properties))
+	for key, value := range properties {
+		elem := reflect.New(mapType.Elem()).Elem()
+		if err := decodeValue(value, elem); err != nil {
+			return fmt.Errorf("key %q: %w", key, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(mapType.Key()), elem)
+	}
+	target.Set(result)
+	return nil
+}
+
+func decodeStruct(properties map[string]interface{}, target reflect.Value) error {
+	for _, field := range structs.Fields(target.Type()) {
+		value, found := properties[field.Name]
+		if !found {
+			continue
+		}
+		if err := decodeValue(value, target.FieldByIndex(field.Index)); err != nil {
+			return fmt.Errorf("key %q: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func propertiesOf(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case dbtype.Node:
+		return v.Props, true
+	case dbtype.Relationship:
+		return v.Props, true
+	}
+	return nil, false
+}
+
+func temporalTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case dbtype.Date:
+		return v.Time(), true
+	case dbtype.LocalTime:
+		return v.Time(), true
+	case dbtype.LocalDateTime:
+		return v.Time(), true
+	case dbtype.Time:
+		return v.Time(), true
+	}
+	return time.Time{}, false
+}
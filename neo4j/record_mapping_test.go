@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+type mappedAddress struct {
+	City    string `neo4j:"city"`
+	ZipCode *int   `neo4j:"zip"`
+}
+
+type mappedAudit struct {
+	CreatedAt time.Time `neo4j:"createdAt"`
+}
+
+type mappedPerson struct {
+	Name     string         `neo4j:"name"`
+	Age      int32          `neo4j:"age"`
+	Score    float32        `neo4j:"score"`
+	Nickname *string        `neo4j:"nickname"`
+	Tags     []string       `neo4j:"tags"`
+	Address  mappedAddress  `neo4j:"address"`
+	Extra    map[string]int `neo4j:"extra"`
+	Node     dbtype.Node    `neo4j:"node"`
+	Raw      interface{}    `neo4j:"raw"`
+	Ignored  string         `neo4j:"-"`
+	Untagged bool
+	ignored  string
+	mappedAudit
+}
+
+func TestRecordAs(outer *testing.T) {
+	newRecord := func(values map[string]interface{}) *Record {
+		record := &Record{}
+		for key, value := range values {
+			record.Keys = append(record.Keys, key)
+			record.Values = append(record.Values, value)
+		}
+		return record
+	}
+
+	validValues := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":      "Alice",
+			"age":       int64(42),
+			"score":     int64(3),
+			"nickname":  nil,
+			"tags":      []interface{}{"a", "b"},
+			"address":   dbtype.Node{Labels: []string{"Address"}, Props: map[string]interface{}{"city": "Malmö", "zip": int64(21119)}},
+			"extra":     map[string]interface{}{"x": int64(1)},
+			"node":      dbtype.Node{ElementId: "n1"},
+			"raw":       []interface{}{int64(1)},
+			"Untagged":  true,
+			"createdAt": dbtype.LocalDateTime(time.Date(2023, 1, 2, 3, 4, 5, 0, time.Local)),
+		}
+	}
+
+	outer.Run("maps values to tagged and untagged fields", func(t *testing.T) {
+		person, err := RecordAs[mappedPerson](newRecord(validValues()))
+
+		AssertNoError(t, err)
+		zip := 21119
+		AssertDeepEquals(t, person, mappedPerson{
+			Name:        "Alice",
+			Age:         42,
+			Score:       3,
+			Tags:        []string{"a", "b"},
+			Address:     mappedAddress{City: "Malmö", ZipCode: &zip},
+			Extra:       map[string]int{"x": 1},
+			Node:        dbtype.Node{ElementId: "n1"},
+			Raw:         []interface{}{int64(1)},
+			Untagged:    true,
+			mappedAudit: mappedAudit{CreatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.Local)},
+		})
+	})
+
+	outer.Run("maps non-null values to pointer fields", func(t *testing.T) {
+		values := validValues()
+		values["nickname"] = "Al"
+
+		person, err := RecordAs[mappedPerson](newRecord(values))
+
+		AssertNoError(t, err)
+		AssertStringEqual(t, *person.Nickname, "Al")
+	})
+
+	outer.Run("leaves fields of missing properties untouched", func(t *testing.T) {
+		values := validValues()
+		values["address"] = map[string]interface{}{"city": "Malmö"}
+
+		person, err := RecordAs[mappedPerson](newRecord(values))
+
+		AssertNoError(t, err)
+		AssertNil(t, person.Address.ZipCode)
+	})
+
+	outer.Run("fails on missing keys", func(t *testing.T) {
+		values := validValues()
+		delete(values, "age")
+
+		_, err := RecordAs[mappedPerson](newRecord(values))
+
+		AssertErrorMessageContains(t, err, `key "age" not found`)
+	})
+
+	outer.Run("fails on incompatible values", func(t *testing.T) {
+		values := validValues()
+		values["tags"] = []interface{}{"a", int64(1)}
+
+		_, err := RecordAs[mappedPerson](newRecord(values))
+
+		AssertErrorMessageContains(t, err, `key "tags": index 1: cannot decode int64 into string`)
+	})
+
+	outer.Run("fails on overflowing integers", func(t *testing.T) {
+		values := validValues()
+		values["age"] = int64(1) << 40
+
+		_, err := RecordAs[mappedPerson](newRecord(values))
+
+		AssertErrorMessageContains(t, err, `key "age": cannot decode int64 into int32`)
+	})
+
+	outer.Run("fails on incompatible nested properties", func(t *testing.T) {
+		values := validValues()
+		values["address"] = dbtype.Node{Props: map[string]interface{}{"zip": "21119"}}
+
+		_, err := RecordAs[mappedPerson](newRecord(values))
+
+		AssertErrorMessageContains(t, err, `key "address": key "zip": cannot decode string into int`)
+	})
+
+	outer.Run("fails on values that cannot be decoded lazily", func(t *testing.T) {
+		decodeErr := errors.New("cannot decode")
+		record := db.NewLazyRecord(1, func(int) (interface{}, error) {
+			return nil, decodeErr
+		})
+		record.Keys = []string{"name"}
+
+		type named struct {
+			Name *string `neo4j:"name"`
+		}
+
+		_, err := RecordAs[named](record)
+
+		AssertTrue(t, errors.Is(err, decodeErr))
+		AssertErrorMessageContains(t, err, `key "name"`)
+	})
+
+	outer.Run("fails on non-struct types", func(t *testing.T) {
+		_, err := RecordAs[string](newRecord(validValues()))
+
+		AssertErrorMessageContains(t, err, "Expected struct type")
+	})
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"fmt"
+	"time"
+)
+
+// PropertyValue is the set of types that property values of nodes and relationships are decoded to.
+type PropertyValue interface {
+	bool | int64 | float64 | string |
+		Point2D | Point3D |
+		Date | LocalTime | LocalDateTime | Time | Duration | time.Time |
+		[]byte | []interface{}
+}
+
+// RecordValue is the set of types that record values are decoded to.
+type RecordValue interface {
+	PropertyValue | Node | Relationship | Path | map[string]interface{}
+}
+
+// GetRecordValue returns the value of the record for the given key as a T.
+//
+// The returned boolean is true when the value is null, in which case the zero value of T is returned.
+// An error naming the key is returned when the record has no value for the key, when the value
+// cannot be decoded with Config.LazyRecordDecoding, see Record.Err, or when the value is not a T:
+//
+//	name, isNil, err := neo4j.GetRecordValue[string](record, "name")
+func GetRecordValue[T RecordValue](record *Record, key string) (T, bool, error) {
+	var zero T
+	if record == nil {
+		return zero, false, &UsageError{Message: fmt.Sprintf("Cannot get value for key %q from nil record", key)}
+	}
+	rawValue, found := record.Get(key)
+	if !found {
+		return zero, false, &UsageError{Message: fmt.Sprintf("Record has no value for key %q, available keys are %q", key, record.Keys)}
+	}
+	if err := record.Err(); err != nil {
+		return zero, false, fmt.Errorf("cannot decode value for key %q: %w", key, err)
+	}
+	if rawValue == nil {
+		return zero, true, nil
+	}
+	value, ok := rawValue.(T)
+	if !ok {
+		return zero, false, &UsageError{Message: fmt.Sprintf("Expected value for key %q to be of type %T but was %T", key, zero, rawValue)}
+	}
+	return value, false, nil
+}
+
+// GetProperty returns the property of the node or relationship for the given key as a T.
+//
+// Null properties are not stored by the database, so an error naming the key is returned when the
+// entity has no such property, as well as when the property is not a T:
+//
+//	age, err := neo4j.GetProperty[int64](node, "age")
+func GetProperty[T PropertyValue](entity Entity, key string) (T, error) {
+	var zero T
+	if entity == nil {
+		return zero, &UsageError{Message: fmt.Sprintf("Cannot get property %q from nil entity", key)}
+	}
+	rawValue, found := entity.GetProperties()[key]
+	if !found {
+		return zero, &UsageError{Message: fmt.Sprintf("%T %q has no property %q", entity, entity.GetElementId(), key)}
+	}
+	value, ok := rawValue.(T)
+	if !ok {
+		return zero, &UsageError{Message: fmt.Sprintf("Expected property %q of %T %q to be of type %T but was %T", key, entity, entity.GetElementId(), zero, rawValue)}
+	}
+	return value, nil
+}
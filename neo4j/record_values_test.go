@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestGetRecordValue(outer *testing.T) {
+	node := Node{ElementId: "4:db:1", Labels: []string{"Person"}, Props: map[string]interface{}{"name": "Alice"}}
+	record := &Record{
+		Keys:   []string{"name", "age", "node", "nothing"},
+		Values: []interface{}{"Alice", int64(42), node, nil},
+	}
+
+	outer.Run("returns values of the expected type", func(t *testing.T) {
+		name, isNil, err := GetRecordValue[string](record, "name")
+		AssertNoError(t, err)
+		AssertFalse(t, isNil)
+		AssertStringEqual(t, name, "Alice")
+
+		n, isNil, err := GetRecordValue[Node](record, "node")
+		AssertNoError(t, err)
+		AssertFalse(t, isNil)
+		AssertDeepEquals(t, n, node)
+	})
+
+	outer.Run("flags null values", func(t *testing.T) {
+		value, isNil, err := GetRecordValue[int64](record, "nothing")
+
+		AssertNoError(t, err)
+		AssertTrue(t, isNil)
+		AssertDeepEquals(t, value, int64(0))
+	})
+
+	outer.Run("fails on missing keys", func(t *testing.T) {
+		_, isNil, err := GetRecordValue[int64](record, "height")
+
+		AssertFalse(t, isNil)
+		AssertErrorMessageContains(t, err, `Record has no value for key "height"`)
+	})
+
+	outer.Run("fails on values of another type", func(t *testing.T) {
+		_, _, err := GetRecordValue[string](record, "age")
+
+		AssertErrorMessageContains(t, err, `Expected value for key "age" to be of type string but was int64`)
+	})
+
+	outer.Run("fails on values that cannot be decoded lazily", func(t *testing.T) {
+		decodeErr := errors.New("cannot decode")
+		lazyRecord := db.NewLazyRecord(1, func(int) (interface{}, error) {
+			return nil, decodeErr
+		})
+		lazyRecord.Keys = []string{"name"}
+
+		_, isNil, err := GetRecordValue[string](lazyRecord, "name")
+
+		AssertFalse(t, isNil)
+		AssertTrue(t, errors.Is(err, decodeErr))
+		AssertErrorMessageContains(t, err, `key "name"`)
+	})
+
+	outer.Run("fails on nil records", func(t *testing.T) {
+		_, _, err := GetRecordValue[string](nil, "name")
+
+		AssertErrorMessageContains(t, err, "nil record")
+	})
+}
+
+func TestGetProperty(outer *testing.T) {
+	node := Node{ElementId: "4:db:1", Props: map[string]interface{}{"name": "Alice", "age": int64(42)}}
+	relationship := Relationship{ElementId: "5:db:2", Props: map[string]interface{}{"since": int64(2012)}}
+
+	outer.Run("returns properties of the expected type", func(t *testing.T) {
+		name, err := GetProperty[string](node, "name")
+		AssertNoError(t, err)
+		AssertStringEqual(t, name, "Alice")
+
+		since, err := GetProperty[int64](relationship, "since")
+		AssertNoError(t, err)
+		AssertDeepEquals(t, since, int64(2012))
+	})
+
+	outer.Run("fails on missing properties", func(t *testing.T) {
+		_, err := GetProperty[string](node, "email")
+
+		AssertErrorMessageContains(t, err, `dbtype.Node "4:db:1" has no property "email"`)
+	})
+
+	outer.Run("fails on properties of another type", func(t *testing.T) {
+		_, err := GetProperty[float64](node, "age")
+
+		AssertErrorMessageContains(t, err, `Expected property "age" of dbtype.Node "4:db:1" to be of type float64 but was int64`)
+	})
+
+	outer.Run("fails on nil entities", func(t *testing.T) {
+		_, err := GetProperty[string](nil, "name")
+
+		AssertErrorMessageContains(t, err, "nil entity")
+	})
+}
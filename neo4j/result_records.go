@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import "context"
+
+// StreamedRecord is delivered by ResultWithContext.Stream.
+// Either Record is set, or Err is set to the error that ended the stream.
+type StreamedRecord struct {
+	Record *Record
+	Err    error
+}
+
+func (r *resultWithContext) Records(ctx context.Context) RecordSeq {
+	return func(yield func(*Record, error) bool) {
+		for r.Next(ctx) {
+			if !yield(r.record, nil) {
+				return
+			}
+		}
+		if err := r.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func (r *resultWithContext) Stream(ctx context.Context, bufferSize int) <-chan StreamedRecord {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	records := make(chan StreamedRecord, bufferSize)
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	r.stopStream = func() {
+		close(stop)
+		<-stopped
+	}
+	// Delivers the record unless the stream is stopped meanwhile, returns whether it was delivered
+	deliver := func(record StreamedRecord) bool {
+		select {
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		default:
+		}
+		select {
+		case records <- record:
+			return true
+		case <-stop:
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+	go func() {
+		defer close(stopped)
+		defer close(records)
+		// Blocking on a full channel stops fetching until the consumer catches up
+		for r.Next(ctx) {
+			if !deliver(StreamedRecord{Record: r.record}) {
+				return
+			}
+		}
+		if err := r.Err(); err != nil {
+			deliver(StreamedRecord{Err: err})
+		}
+	}()
+	return records
+}
@@ -0,0 +1,46 @@
+//go:build go1.23
+
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestResultRecordsRange(t *testing.T) {
+	ctx := context.Background()
+	record1 := &db.Record{Keys: []string{"n"}, Values: []interface{}{int64(1)}}
+	record2 := &db.Record{Keys: []string{"n"}, Values: []interface{}{int64(2)}}
+	conn := &ConnFake{Nexts: []Next{{Record: record1}, {Record: record2}, {Summary: &db.Summary{}}}}
+	result := newResultWithContext(conn, nil, "", nil)
+
+	var records []*Record
+	for record, err := range result.Records(ctx) {
+		AssertNoError(t, err)
+		records = append(records, record)
+	}
+
+	AssertDeepEquals(t, records, []*Record{record1, record2})
+}
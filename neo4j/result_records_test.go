@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
+	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
+)
+
+func TestResultRecords(outer *testing.T) {
+	ctx := context.Background()
+	record1 := &db.Record{Keys: []string{"n"}, Values: []interface{}{int64(1)}}
+	record2 := &db.Record{Keys: []string{"n"}, Values: []interface{}{int64(2)}}
+	streamErr := errors.New("oopsie")
+
+	collect := func(seq RecordSeq) ([]*Record, []error) {
+		var records []*Record
+		var errs []error
+		seq(func(record *Record, err error) bool {
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				records = append(records, record)
+			}
+			return true
+		})
+		return records, errs
+	}
+
+	outer.Run("Records yields all records", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: record1}, {Record: record2}, {Summary: &db.Summary{}}}}
+		result := newResultWithContext(conn, nil, "", nil)
+
+		records, errs := collect(result.Records(ctx))
+
+		AssertDeepEquals(t, records, []*Record{record1, record2})
+		AssertLen(t, errs, 0)
+	})
+
+	outer.Run("Records yields the error that ends the stream", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: record1}, {Err: streamErr}}}
+		result := newResultWithContext(conn, nil, "", nil)
+
+		records, errs := collect(result.Records(ctx))
+
+		AssertDeepEquals(t, records, []*Record{record1})
+		AssertLen(t, errs, 1)
+		AssertErrorMessageContains(t, errs[0], "oopsie")
+	})
+
+	outer.Run("Records stops when asked to", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: record1}, {Record: record2}, {Summary: &db.Summary{}}}}
+		result := newResultWithContext(conn, nil, "", nil)
+		calls := 0
+
+		result.Records(ctx)(func(*Record, error) bool {
+			calls++
+			return false
+		})
+
+		AssertIntEqual(t, calls, 1)
+		AssertTrue(t, result.Next(ctx))
+		AssertDeepEquals(t, result.Record(), record2)
+	})
+
+	outer.Run("Stream delivers all records and closes", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: record1}, {Record: record2}, {Summary: &db.Summary{}}}}
+		result := newResultWithContext(conn, nil, "", nil)
+
+		var records []*Record
+		for streamed := range result.Stream(ctx, 1) {
+			AssertNoError(t, streamed.Err)
+			records = append(records, streamed.Record)
+		}
+
+		AssertDeepEquals(t, records, []*Record{record1, record2})
+	})
+
+	outer.Run("Stream delivers the error that ends the stream", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: record1}, {Err: streamErr}}}
+		result := newResultWithContext(conn, nil, "", nil)
+
+		var streamed []StreamedRecord
+		for s := range result.Stream(ctx, 0) {
+			streamed = append(streamed, s)
+		}
+
+		AssertLen(t, streamed, 2)
+		AssertDeepEquals(t, streamed[0].Record, record1)
+		AssertNil(t, streamed[1].Record)
+		AssertErrorMessageContains(t, streamed[1].Err, "oopsie")
+	})
+
+	outer.Run("Stream closes when the context is done", func(t *testing.T) {
+		records := make([]Next, 100)
+		for i := range records {
+			records[i] = Next{Record: record1}
+		}
+		conn := &ConnFake{Nexts: append(records, Next{Summary: &db.Summary{}})}
+		result := newResultWithContext(conn, nil, "", nil)
+		streamCtx, cancel := context.WithCancel(ctx)
+
+		stream := result.Stream(streamCtx, 0)
+		<-stream
+		cancel()
+		received := 1
+		for range stream {
+			received++
+		}
+
+		// the consumer may still receive a few records sent concurrently with the cancellation
+		AssertTrue(t, received < 100)
+	})
+
+	outer.Run("Stream closes when the result is consumed", func(t *testing.T) {
+		conn := &ConnFake{Nexts: []Next{{Record: record1}, {Record: record2}, {Summary: &db.Summary{}}}}
+		result := newResultWithContext(conn, nil, "", nil)
+
+		stream := result.Stream(ctx, 0)
+		<-stream
+		_, err := result.Consume(ctx)
+
+		AssertNoError(t, err)
+		for range stream {
+			t.Error("should not deliver records once consumed")
+		}
+	})
+}
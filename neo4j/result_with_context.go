@@ -23,6 +23,7 @@ import (
 	"context"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/db"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
 )
 
 type ResultWithContext interface {
@@ -53,6 +54,31 @@ type ResultWithContext interface {
 	Consume(ctx context.Context) (ResultSummary, error)
 	// IsOpen determines whether this result cursor is available
 	IsOpen() bool
+	// Records returns an iterator over the remaining records.
+	// The iterator yields each record with a nil error. If fetching records fails, it yields the
+	// error with a nil record and stops. Breaking out of the iteration leaves the remaining records
+	// in the result.
+	// From Go 1.23, the iterator is an iter.Seq2 and can be used with range:
+	//
+	//	for record, err := range result.Records(ctx) {
+	//		if err != nil {
+	//			return err
+	//		}
+	//		...
+	//	}
+	Records(ctx context.Context) RecordSeq
+	// Stream fetches the remaining records in the background and delivers them over the returned channel.
+	// The channel is closed once all records are delivered, after delivering the error that ended the
+	// stream if any, when the context is done, or when the result is consumed or its transaction or session
+	// is closed.
+	// The next record is only fetched once the channel buffer has room for it, so that at most bufferSize
+	// records wait in the channel. This does not change how records are pulled from the server: they are
+	// still pulled in batches of the configured fetch size and held by the connection until fetched.
+	// Callers must drain the channel, cancel the context, consume the result or close its transaction or
+	// session. Otherwise, the background goroutine blocks forever and the connection is never returned
+	// to the pool. Apart from that, the result, its transaction and its session must not be used until the
+	// channel is closed.
+	Stream(ctx context.Context, bufferSize int) <-chan StreamedRecord
 	legacy() *result
 }
 
@@ -69,6 +95,12 @@ type resultWithContext struct {
 	peekedRecord  *Record
 	peekedSummary *db.Summary
 	peeked        bool
+	tracer        *tracer
+	// pull span, started with the first fetch and ended once the summary or an error is received
+	pullSpan    tracing.Span
+	pullStarted bool
+	// stops the goroutine delivering the records over a channel and waits for it to exit, set by Stream
+	stopStream func()
 }
 
 func newResultWithContext(conn idb.Connection, str idb.StreamHandle,
@@ -95,6 +127,7 @@ func (r *resultWithContext) NextRecord(ctx context.Context, out **Record) bool {
 
 func (r *resultWithContext) Next(ctx context.Context) bool {
 	r.checkOpen()
+	r.checkRecord()
 	if r.err != nil {
 		return false
 	}
@@ -112,6 +145,7 @@ func (r *resultWithContext) PeekRecord(ctx context.Context, out **Record) bool {
 
 func (r *resultWithContext) Peek(ctx context.Context) bool {
 	r.checkOpen()
+	r.checkRecord()
 	if r.err != nil {
 		return false
 	}
@@ -120,6 +154,7 @@ func (r *resultWithContext) Peek(ctx context.Context) bool {
 }
 
 func (r *resultWithContext) Err() error {
+	r.checkRecord()
 	return wrapError(r.err)
 }
 
@@ -163,7 +198,9 @@ func (r *resultWithContext) Single(ctx context.Context) (*Record, error) {
 	if r.record != nil {
 		// There were more records, consume the stream since the user didn't
 		// expect more records and should therefore not use them.
-		r.summary, _ = r.conn.Consume(ctx, r.streamHandle)
+		var err error
+		r.summary, err = r.conn.Consume(ctx, r.streamHandle)
+		r.endPull(err)
 		r.err = &UsageError{Message: "Result contains more than one record"}
 		r.record = nil
 		return nil, r.err
@@ -180,6 +217,7 @@ func (r *resultWithContext) Single(ctx context.Context) (*Record, error) {
 }
 
 func (r *resultWithContext) Consume(ctx context.Context) (ResultSummary, error) {
+	r.stopStreaming()
 	// Already failed, reuse the internal error, might have been
 	// set by Single to indicate some kind of usage error that "destroyed"
 	// the result.
@@ -188,7 +226,9 @@ func (r *resultWithContext) Consume(ctx context.Context) (ResultSummary, error)
 	}
 
 	r.record = nil
+	r.startPull(ctx)
 	r.summary, r.err = r.conn.Consume(ctx, r.streamHandle)
+	r.endPull(r.err)
 	if r.err != nil {
 		return nil, wrapError(r.err)
 	}
@@ -204,7 +244,10 @@ func (r *resultWithContext) legacy() *result {
 }
 
 func (r *resultWithContext) buffer(ctx context.Context) {
+	r.stopStreaming()
+	r.startPull(ctx)
 	r.err = r.conn.Buffer(ctx, r.streamHandle)
+	r.endPull(r.err)
 }
 
 func (r *resultWithContext) toResultSummary() ResultSummary {
@@ -221,17 +264,73 @@ func (r *resultWithContext) advance(ctx context.Context) {
 		r.summary, r.peekedSummary = r.peekedSummary, nil
 		r.peeked = false
 	} else {
+		r.startPull(ctx)
 		r.record, r.summary, r.err = r.conn.Next(ctx, r.streamHandle)
+		if r.summary != nil || r.err != nil {
+			r.endPull(r.err)
+		}
 	}
 }
 
 func (r *resultWithContext) peek(ctx context.Context) {
 	if !r.peeked {
+		r.startPull(ctx)
 		r.peekedRecord, r.peekedSummary, r.err = r.conn.Next(ctx, r.streamHandle)
+		if r.peekedSummary != nil || r.err != nil {
+			r.endPull(r.err)
+		}
 		r.peeked = true
 	}
 }
 
+func (r *resultWithContext) startPull(ctx context.Context) {
+	if r.tracer != nil && !r.pullStarted {
+		r.pullStarted = true
+		_, r.pullSpan = r.tracer.start(ctx, tracing.Pull)
+	}
+}
+
+func (r *resultWithContext) endPull(err error) {
+	if r.pullSpan != nil {
+		r.pullSpan.End(wrapError(err))
+		r.pullSpan = nil
+	}
+}
+
+// Stops streaming the records, if streamed, so that the connection can be used by the caller
+func (r *resultWithContext) stopStreaming() {
+	if r.stopStream != nil {
+		r.stopStream()
+		r.stopStream = nil
+	}
+}
+
+// Stops streaming the results of a transaction about to end, see stopStreaming
+func stopStreams(results []*resultWithContext) {
+	for _, result := range results {
+		result.stopStreaming()
+	}
+}
+
+// Ends the pull spans left open by results their transaction cut short, the results were not fully consumed
+func endPulls(results []*resultWithContext, err error) {
+	for _, result := range results {
+		result.endPull(err)
+	}
+}
+
+// Fails the result when values of the current record, decoded lazily, could not be decoded.
+// The result then stops at that record, as it does when records are decoded eagerly.
+func (r *resultWithContext) checkRecord() {
+	if r.err != nil || r.record == nil {
+		return
+	}
+	if err := r.record.Err(); err != nil {
+		r.err = err
+		r.record = nil
+	}
+}
+
 func (r *resultWithContext) checkOpen() {
 	alreadyChecked := r.err != nil && r.err.Error() == consumedResultError
 	if !alreadyChecked && !r.isOpen() {
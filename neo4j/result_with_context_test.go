@@ -389,6 +389,25 @@ func TestResult(outer *testing.T) {
 		AssertNotNil(t, res.Err())
 	})
 
+	outer.Run("Stops at lazy records that cannot be decoded", func(t *testing.T) {
+		decodeErr := errors.New("unknown struct")
+		lazyRecord := db.NewLazyRecord(1, func(int) (interface{}, error) {
+			return nil, decodeErr
+		})
+		lazyRecord.Keys = []string{"n"}
+		conn := &ConnFake{Nexts: []Next{{Record: lazyRecord}, {Record: recs[1]}, {Summary: sums[0]}}}
+		res := newResultWithContext(conn, streamHandle, cypher, params)
+
+		AssertTrue(t, res.Next(ctx))
+		value, found := res.Record().Get("n")
+		AssertTrue(t, found)
+		AssertNil(t, value)
+
+		AssertFalse(t, res.Next(ctx))
+		AssertNil(t, res.Record())
+		AssertErrorMessageContains(t, res.Err(), "unknown struct")
+	})
+
 	outer.Run("IsOpen", func(t *testing.T) {
 		openResult := &resultWithContext{summary: nil}
 		closedResult := &resultWithContext{summary: &db.Summary{}}
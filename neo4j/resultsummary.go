@@ -216,10 +216,48 @@ type Notification interface {
 	// Position returns the position in the statement where this notification points to.
 	// Not all notifications have a unique position to point to and in that case the position would be set to nil.
 	Position() InputPosition
-	// Severity returns the severity level of this notification.
+	// Severity returns the severity level of this notification, as sent by the server.
 	Severity() string
+	// SeverityLevel returns the severity level of this notification.
+	// SeverityUnknown is returned when the server sends a severity level the driver does not know of.
+	SeverityLevel() NotificationSeverity
+	// RawCategory returns the category of this notification, as sent by the server.
+	// Servers older than 5.7 do not send categories, in which case the category is empty.
+	RawCategory() string
+	// Category returns the category of this notification.
+	// CategoryUnknown is returned when the server sends no category or a category the driver does not know of.
+	Category() NotificationCategory
 }
 
+// NotificationSeverity is the severity level of a notification
+type NotificationSeverity string
+
+const (
+	SeverityWarning     NotificationSeverity = "WARNING"
+	SeverityInformation NotificationSeverity = "INFORMATION"
+	SeverityUnknown     NotificationSeverity = "UNKNOWN"
+)
+
+// NotificationCategory is the category of a notification
+type NotificationCategory string
+
+const (
+	// CategoryHint is the category of notifications about a query that cannot be executed as hinted
+	CategoryHint NotificationCategory = "HINT"
+	// CategoryUnrecognized is the category of notifications about unknown entities referred to by a query
+	CategoryUnrecognized NotificationCategory = "UNRECOGNIZED"
+	// CategoryUnsupported is the category of notifications about unsupported or experimental features
+	CategoryUnsupported NotificationCategory = "UNSUPPORTED"
+	// CategoryPerformance is the category of notifications about potentially expensive queries
+	CategoryPerformance NotificationCategory = "PERFORMANCE"
+	// CategoryDeprecation is the category of notifications about deprecated features
+	CategoryDeprecation NotificationCategory = "DEPRECATION"
+	// CategoryGeneric is the category of notifications that do not fit any other category
+	CategoryGeneric NotificationCategory = "GENERIC"
+	// CategoryUnknown is used for notifications without category or with a category the driver does not know of
+	CategoryUnknown NotificationCategory = "UNKNOWN"
+)
+
 // InputPosition contains information about a specific position in a statement
 type InputPosition interface {
 	// Offset returns the character offset referred to by this position; offset numbers start at 0.
@@ -495,6 +533,26 @@ func (n *notification) Severity() string {
 	return n.notification.Severity
 }
 
+func (n *notification) SeverityLevel() NotificationSeverity {
+	switch severity := NotificationSeverity(n.notification.Severity); severity {
+	case SeverityWarning, SeverityInformation:
+		return severity
+	}
+	return SeverityUnknown
+}
+
+func (n *notification) RawCategory() string {
+	return n.notification.Category
+}
+
+func (n *notification) Category() NotificationCategory {
+	switch category := NotificationCategory(n.notification.Category); category {
+	case CategoryHint, CategoryUnrecognized, CategoryUnsupported, CategoryPerformance, CategoryDeprecation, CategoryGeneric:
+		return category
+	}
+	return CategoryUnknown
+}
+
 func (n *notification) Position() InputPosition {
 	if n.notification.Position == nil {
 		return nil
@@ -81,6 +81,31 @@ func TestNotifications(st *testing.T) {
 			t.Errorf("Expected %v to equal %v", received, expected)
 		}
 	})
+
+	st.Run("Known severity levels and categories are mapped", func(t *testing.T) {
+		notif := notification{notification: &db.Notification{Severity: "WARNING", Category: "DEPRECATION"}}
+
+		if notif.SeverityLevel() != SeverityWarning {
+			t.Errorf("Expected severity level %v but got %v", SeverityWarning, notif.SeverityLevel())
+		}
+		if notif.Category() != CategoryDeprecation {
+			t.Errorf("Expected category %v but got %v", CategoryDeprecation, notif.Category())
+		}
+	})
+
+	st.Run("Unknown severity levels and categories are mapped to unknown", func(t *testing.T) {
+		notif := notification{notification: &db.Notification{Severity: "SPICY", Category: "WHATEVER"}}
+
+		if notif.SeverityLevel() != SeverityUnknown {
+			t.Errorf("Expected severity level %v but got %v", SeverityUnknown, notif.SeverityLevel())
+		}
+		if notif.Category() != CategoryUnknown {
+			t.Errorf("Expected category %v but got %v", CategoryUnknown, notif.Category())
+		}
+		if notif.RawCategory() != "WHATEVER" {
+			t.Errorf("Expected raw category WHATEVER but got %v", notif.RawCategory())
+		}
+	})
 }
 
 func TestCounters(st *testing.T) {
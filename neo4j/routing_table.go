@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"time"
+
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/router"
+)
+
+// RoutingTable is a snapshot of the routing table of a database, as known by the driver
+type RoutingTable struct {
+	// DatabaseName is the name of the database the routing table belongs to
+	DatabaseName string
+	// Routers are the servers the routing table is read from
+	Routers []string
+	// Readers are the servers serving reads on the database
+	Readers []string
+	// Writers are the servers serving writes on the database, i.e. the leader of the database
+	Writers []string
+	// TimeToLive is the time to live of the routing table, as sent by the server
+	TimeToLive time.Duration
+	// ExpiresAt is the time after which the driver reads the routing table again
+	ExpiresAt time.Time
+}
+
+func newRoutingTable(table *idb.RoutingTable, expiresAt time.Time) RoutingTable {
+	return RoutingTable{
+		DatabaseName: table.DatabaseName,
+		Routers:      table.Routers,
+		Readers:      table.Readers,
+		Writers:      table.Writers,
+		TimeToLive:   time.Duration(table.TimeToLive) * time.Second,
+		ExpiresAt:    expiresAt,
+	}
+}
+
+// RoutingTableChangeReason is the reason of a routing table change
+type RoutingTableChangeReason string
+
+const (
+	// RoutingTableUpdated is the reason of changes made by reading a new routing table from the cluster
+	RoutingTableUpdated RoutingTableChangeReason = router.TableUpdated
+	// RoutingTableServerRemoved is the reason of changes made by the driver removing a reader or a writer
+	// after failing to use it, until the next routing table is read
+	RoutingTableServerRemoved RoutingTableChangeReason = router.ServerRemoved
+	// RoutingTableRemoved is the reason of changes made by the driver removing an expired routing table
+	// of a database it does not use anymore
+	RoutingTableRemoved RoutingTableChangeReason = router.TableRemoved
+)
+
+// RoutingTableEvent describes a change of the routing table of a database
+type RoutingTableEvent struct {
+	Reason       RoutingTableChangeReason
+	DatabaseName string
+	// RoutingPolicy is the routing policy the routing table was read for, see Config.RoutingPolicy
+	RoutingPolicy string
+	// Before is the routing table before the change, nil when the driver had no routing table for the database
+	Before *RoutingTable
+	// After is the routing table after the change, nil when the routing table is removed
+	After          *RoutingTable
+	AddedRouters   []string
+	RemovedRouters []string
+	AddedReaders   []string
+	RemovedReaders []string
+	// AddedWriters and RemovedWriters are both set when the leader of the database changed
+	AddedWriters   []string
+	RemovedWriters []string
+}
+
+// RoutingTableListener is notified of the changes of the routing tables, see Config.RoutingTableListener.
+// It may be called from several goroutines at the same time.
+type RoutingTableListener func(event RoutingTableEvent)
+
+func toTableListener(listener RoutingTableListener) router.TableListener {
+	if listener == nil {
+		return nil
+	}
+	return func(change router.TableChange) {
+		listener(RoutingTableEvent{
+			Reason:         RoutingTableChangeReason(change.Reason),
+			DatabaseName:   change.Database,
+			RoutingPolicy:  change.Policy,
+			Before:         toRoutingTableSnapshot(change.Before),
+			After:          toRoutingTableSnapshot(change.After),
+			AddedRouters:   change.Routers.Added,
+			RemovedRouters: change.Routers.Removed,
+			AddedReaders:   change.Readers.Added,
+			RemovedReaders: change.Readers.Removed,
+			AddedWriters:   change.Writers.Added,
+			RemovedWriters: change.Writers.Removed,
+		})
+	}
+}
+
+func toRoutingTableSnapshot(snapshot *router.TableSnapshot) *RoutingTable {
+	if snapshot == nil {
+		return nil
+	}
+	table := newRoutingTable(snapshot.Table, snapshot.ExpiresAt)
+	return &table
+}
@@ -21,14 +21,15 @@ package neo4j
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
-	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/pool"
 	"math"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
 )
 
 // TransactionWork represents a unit of work that will be executed against the provided
@@ -106,6 +107,48 @@ type SessionConfig struct {
 	// to the correct cluster member (different databases may have different
 	// leaders).
 	ImpersonatedUser string
+	// BookmarkManager defines a central point to externally supply bookmarks
+	// and be notified of bookmark updates per database
+	// This API is experimental and may be changed or removed without prior notice
+	//
+	// Sessions sharing the same BookmarkManager are causally chained: every
+	// transaction started by one of these sessions waits for the server to
+	// have caught up with the bookmarks the manager knows of for the target
+	// database, in addition to the initial Bookmarks of the session.
+	//
+	// default: nil (no bookmark manager)
+	BookmarkManager BookmarkManager
+	// Auth is used to overwrite the authentication information for the session.
+	// This requires the server to support re-authentication on the protocol level (Bolt 5.1+)
+	// when connections are reused, older servers are supported by closing pooled connections
+	// authenticated with another token and opening new ones instead.
+	// `nil` will make the driver use the authentication information from the driver configuration.
+	//
+	// Connections authenticated with the session token are returned to the shared pool and
+	// re-authenticated when borrowed by another session, sessions preferably pick up connections
+	// already authenticated with their own token.
+	//
+	// default: nil (use the driver's authentication)
+	Auth *AuthToken
+	// NotificationsMinSeverity overrides Config.NotificationsMinSeverity for the session.
+	// Requires Bolt 5.2 (Neo4j 5.7) or later when set.
+	//
+	// default: NotificationMinSeverityDefault (use the driver's configuration)
+	NotificationsMinSeverity NotificationMinSeverityLevel
+	// NotificationsDisabledCategories overrides Config.NotificationsDisabledCategories for the session.
+	// An empty non-nil slice enables all categories.
+	// Requires Bolt 5.2 (Neo4j 5.7) or later when set.
+	//
+	// default: nil (use the driver's configuration)
+	NotificationsDisabledCategories []NotificationCategory
+	// RoutingPolicy overrides Config.RoutingPolicy for the session.
+	// Routing tables are cached per database and routing policy.
+	// Routing policies require a neo4j URI scheme.
+	//
+	// default: "" (use the driver's configuration)
+	RoutingPolicy string
+	// true when the session backs a call to ExecuteQuery, set by ExecuteQuery only
+	executeQuery bool
 }
 
 // FetchAll turns off fetching records in batches.
@@ -116,7 +159,7 @@ const FetchDefault = 0
 
 // Connection pool as seen by the session.
 type sessionPool interface {
-	Borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, livenessCheckThreshold time.Duration) (idb.Connection, error)
+	Borrow(ctx context.Context, serverNames []string, wait bool, boltLogger log.BoltLogger, livenessCheckThreshold time.Duration, auth *idb.ReAuthToken) (idb.Connection, error)
 	Return(ctx context.Context, c idb.Connection) error
 	CleanUp(ctx context.Context) error
 }
@@ -125,6 +168,7 @@ type sessionWithContext struct {
 	config           *Config
 	defaultMode      idb.AccessMode
 	bookmarks        []string
+	bookmarkManager  BookmarkManager
 	databaseName     string
 	impersonatedUser string
 	resolveHomeDb    bool
@@ -139,6 +183,18 @@ type sessionWithContext struct {
 	throttleTime     time.Duration
 	fetchSize        int
 	boltLogger       log.BoltLogger
+	auth             *sessionAuth
+	// notification filters overriding the driver ones, sent along with every transaction
+	notificationConfig idb.NotificationConfig
+	// true when the session backs a call to ExecuteQuery, reported as such through telemetry
+	executeQuery bool
+}
+
+type sessionAuth struct {
+	manager     AuthTokenManager
+	fromSession bool
+	// token last supplied by the manager, reported back to the manager on security errors
+	token AuthToken
 }
 
 // Remove empty string bookmarks to check for "bad" callers
@@ -165,7 +221,7 @@ func cleanupBookmarks(bookmarks []string) []string {
 	return cleaned
 }
 
-func newSessionWithContext(config *Config, sessConfig SessionConfig, router sessionRouter, pool sessionPool, logger log.Logger) *sessionWithContext {
+func newSessionWithContext(config *Config, sessConfig SessionConfig, router sessionRouter, pool sessionPool, driverAuth AuthTokenManager, logger log.Logger) *sessionWithContext {
 	logId := log.NewId()
 	logger.Debugf(log.Session, logId, "Created with context")
 
@@ -174,25 +230,48 @@ func newSessionWithContext(config *Config, sessConfig SessionConfig, router sess
 		fetchSize = sessConfig.FetchSize
 	}
 
+	var auth *sessionAuth
+	if sessConfig.Auth != nil {
+		auth = &sessionAuth{manager: sessConfig.Auth, fromSession: true}
+	} else if driverAuth != nil {
+		auth = &sessionAuth{manager: driverAuth}
+	}
+
 	return &sessionWithContext{
 		config:           config,
 		router:           router,
 		pool:             pool,
 		defaultMode:      idb.AccessMode(sessConfig.AccessMode),
 		bookmarks:        cleanupBookmarks(sessConfig.Bookmarks),
+		bookmarkManager:  sessConfig.BookmarkManager,
 		databaseName:     sessConfig.DatabaseName,
 		impersonatedUser: sessConfig.ImpersonatedUser,
 		resolveHomeDb:    sessConfig.DatabaseName == "",
 		sleep:            time.Sleep,
 		now:              time.Now,
-		log:              logger,
+		log:              log.WithDatabase(logger, sessConfig.DatabaseName),
 		logId:            logId,
 		throttleTime:     time.Second * 1,
 		fetchSize:        fetchSize,
 		boltLogger:       sessConfig.BoltLogger,
+		auth:             auth,
+		notificationConfig: toNotificationConfig(sessConfig.NotificationsMinSeverity,
+			sessConfig.NotificationsDisabledCategories),
+		executeQuery: sessConfig.executeQuery,
 	}
 }
 
+// Returns the API reported through telemetry for a transaction started with the given API
+func (s *sessionWithContext) telemetryAPI(api idb.TelemetryAPI) idb.TelemetryAPI {
+	if s.config.TelemetryDisabled {
+		return idb.TelemetryNone
+	}
+	if s.executeQuery && api == idb.TelemetryManagedTransaction {
+		return idb.TelemetryExecuteQuery
+	}
+	return api
+}
+
 func (s *sessionWithContext) LastBookmarks() Bookmarks {
 	// Pick up bookmark from pending auto-commit if there is a bookmark on it
 	if s.autocommitTx != nil {
@@ -226,7 +305,9 @@ func (s *sessionWithContext) BeginTransaction(ctx context.Context, configurers .
 	}
 
 	if s.autocommitTx != nil {
-		s.autocommitTx.done(ctx)
+		if err := s.autocommitTx.done(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	// Apply configuration functions
@@ -239,21 +320,35 @@ func (s *sessionWithContext) BeginTransaction(ctx context.Context, configurers .
 	}
 
 	// Get a connection from the pool. This could fail in clustered environment.
-	conn, err := s.getConnection(ctx, s.defaultMode, pool.DefaultLivenessCheckThreshold)
+	conn, err := s.getConnection(ctx, s.defaultMode, s.config.ConnectionLivenessCheckTimeout)
 	if err != nil {
+		s.handleSecurityError(err)
+		return nil, err
+	}
+
+	beginBookmarks, err := s.getBookmarks(ctx)
+	if err != nil {
+		s.pool.Return(ctx, conn)
 		return nil, err
 	}
 
 	// Begin transaction
-	txHandle, err := conn.TxBegin(ctx,
+	txTracer := s.newTracer(conn, s.defaultMode, beginBookmarks)
+	beginCtx, span := txTracer.start(ctx, tracing.Begin)
+	txHandle, err := conn.TxBegin(beginCtx,
 		idb.TxConfig{
-			Mode:             s.defaultMode,
-			Bookmarks:        s.bookmarks,
-			Timeout:          config.Timeout,
-			Meta:             config.Metadata,
-			ImpersonatedUser: s.impersonatedUser,
+			Mode:               s.defaultMode,
+			Bookmarks:          beginBookmarks,
+			Timeout:            config.Timeout,
+			Meta:               config.Metadata,
+			ImpersonatedUser:   s.impersonatedUser,
+			NotificationConfig: s.notificationConfig,
+			TelemetryAPI:       s.telemetryAPI(idb.TelemetryUnmanagedTransaction),
 		})
+	span.End(wrapError(err))
 	if err != nil {
+		s.handleSecurityError(err)
+		s.handleDatabaseNotFound(ctx, err)
 		s.pool.Return(ctx, conn)
 		return nil, wrapError(err)
 	}
@@ -263,11 +358,13 @@ func (s *sessionWithContext) BeginTransaction(ctx context.Context, configurers .
 		conn:      conn,
 		fetchSize: s.fetchSize,
 		txHandle:  txHandle,
-		onClosed: func() {
+		tracer:    txTracer,
+		onClosed: func(ctx context.Context) error {
 			// On transaction closed (rolled back or committed)
-			s.retrieveBookmarks(conn)
+			err := s.updateBookmarks(ctx, conn, beginBookmarks)
 			s.pool.Return(ctx, conn)
 			s.explicitTx = nil
+			return err
 		},
 	}
 
@@ -298,7 +395,9 @@ func (s *sessionWithContext) runRetriable(
 	}
 
 	if s.autocommitTx != nil {
-		s.autocommitTx.done(ctx)
+		if err := s.autocommitTx.done(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	config := defaultTransactionConfig()
@@ -309,6 +408,9 @@ func (s *sessionWithContext) runRetriable(
 		return nil, err
 	}
 
+	// every retried attempt runs within a retry span
+	attemptCtx := ctx
+	var retrySpan tracing.Span
 	state := retry.State{
 		MaxTransactionRetryTime: s.config.MaxTransactionRetryTime,
 		Log:                     s.log,
@@ -332,10 +434,32 @@ func (s *sessionWithContext) runRetriable(
 			}
 			return nil
 		},
+		OnSecurityError: func(err *Neo4jError) bool {
+			return s.handleSecurityError(err)
+		},
+		OnRetry: func(cause string) {
+			retryTracer := newTracer(s.config, tracing.Attributes{
+				Database:   s.databaseName,
+				AccessMode: traceAccessMode(mode),
+				RetryCause: cause,
+			})
+			attemptCtx, retrySpan = retryTracer.start(ctx, tracing.Retry)
+		},
 	}
 	for state.Continue() {
-		if tryAgain, result := s.executeTransactionFunction(ctx, mode, config, &state, work); tryAgain {
+		tryAgain, result, err := s.executeTransactionFunction(attemptCtx, mode, config, &state, work)
+		if retrySpan != nil {
+			if tryAgain {
+				retrySpan.End(wrapError(state.LastErr))
+			} else {
+				retrySpan.End(err)
+			}
+		}
+		if tryAgain {
 			continue
+		} else if err != nil {
+			s.log.Error(log.Session, s.logId, err)
+			return nil, err
 		} else {
 			return result, nil
 		}
@@ -362,56 +486,122 @@ func (s *sessionWithContext) executeTransactionFunction(
 	mode idb.AccessMode,
 	config TransactionConfig,
 	state *retry.State,
-	work ManagedTransactionWork) (bool, any) {
+	work ManagedTransactionWork) (bool, any, error) {
 
-	conn, err := s.getConnection(ctx, mode, pool.DefaultLivenessCheckThreshold)
+	conn, err := s.getConnection(ctx, mode, s.config.ConnectionLivenessCheckTimeout)
 	if err != nil {
 		state.OnFailure(ctx, conn, err, false)
-		return true, nil
+		return true, nil, nil
 	}
 
 	// handle transaction function panic as well
 	defer s.pool.Return(ctx, conn)
 
-	txHandle, err := conn.TxBegin(ctx,
+	beginBookmarks, err := s.getBookmarks(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	txTracer := s.newTracer(conn, mode, beginBookmarks)
+	beginCtx, span := txTracer.start(ctx, tracing.Begin)
+	txHandle, err := conn.TxBegin(beginCtx,
 		idb.TxConfig{
-			Mode:             mode,
-			Bookmarks:        s.bookmarks,
-			Timeout:          config.Timeout,
-			Meta:             config.Metadata,
-			ImpersonatedUser: s.impersonatedUser,
+			Mode:               mode,
+			Bookmarks:          beginBookmarks,
+			Timeout:            config.Timeout,
+			Meta:               config.Metadata,
+			ImpersonatedUser:   s.impersonatedUser,
+			NotificationConfig: s.notificationConfig,
+			TelemetryAPI:       s.telemetryAPI(idb.TelemetryManagedTransaction),
 		})
+	span.End(wrapError(err))
 	if err != nil {
 		state.OnFailure(ctx, conn, err, false)
-		return true, nil
+		return true, nil, nil
 	}
 
-	tx := managedTransaction{conn: conn, fetchSize: s.fetchSize, txHandle: txHandle}
+	tx := managedTransaction{conn: conn, fetchSize: s.fetchSize, txHandle: txHandle, tracer: txTracer}
+	// handle transaction function panic as well
+	defer tx.endPulls(nil)
 	x, err := work(&tx)
 	if err != nil {
+		tx.endPulls(err)
 		// If the client returns a client specific error that means that
 		// client wants to rollback. We don't do an explicit rollback here
 		// but instead rely on the pool invoking reset on the connection,
 		// that will do an implicit rollback.
 		state.OnFailure(ctx, conn, err, false)
-		return true, nil
+		return true, nil, nil
 	}
 
-	err = conn.TxCommit(ctx, txHandle)
+	stopStreams(tx.results)
+	commitCtx, span := txTracer.start(ctx, tracing.Commit)
+	err = conn.TxCommit(commitCtx, txHandle)
+	span.End(wrapError(err))
+	tx.endPulls(err)
 	if err != nil {
 		state.OnFailure(ctx, conn, err, true)
-		return true, nil
+		return true, nil, nil
 	}
 
-	s.retrieveBookmarks(conn)
-	return false, x
+	// The transaction is committed at this point, retrying is not an option anymore
+	if err = s.updateBookmarks(ctx, conn, beginBookmarks); err != nil {
+		return false, nil, err
+	}
+	return false, x, nil
 }
 
 func (s *sessionWithContext) getServers(ctx context.Context, mode idb.AccessMode) ([]string, error) {
+	bookmarks, err := s.getBookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := s.getAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
 	if mode == idb.ReadMode {
-		return s.router.Readers(ctx, s.bookmarks, s.databaseName, s.boltLogger)
+		return s.router.Readers(ctx, bookmarks, s.databaseName, auth, s.boltLogger)
 	} else {
-		return s.router.Writers(ctx, s.bookmarks, s.databaseName, s.boltLogger)
+		return s.router.Writers(ctx, bookmarks, s.databaseName, auth, s.boltLogger)
+	}
+}
+
+// Retrieves the token connections must be authenticated with, nil means the default driver token.
+// The token is only fetched from the manager when a connection is needed.
+func (s *sessionWithContext) getAuthToken(ctx context.Context) (*idb.ReAuthToken, error) {
+	if s.auth == nil {
+		return nil, nil
+	}
+	token, err := s.auth.manager.GetAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.auth.token = token
+	return &idb.ReAuthToken{Token: token.tokens, FromSession: s.auth.fromSession}, nil
+}
+
+// Notifies the token manager of security errors, returns true when the manager has handled the error
+func (s *sessionWithContext) handleSecurityError(err error) bool {
+	var neo4jErr *Neo4jError
+	if s.auth == nil || !errors.As(err, &neo4jErr) || !neo4jErr.IsSecurityError() {
+		return false
+	}
+	handled := s.auth.manager.HandleSecurityException(s.auth.token, neo4jErr)
+	if handled {
+		s.log.Debugf(log.Session, s.logId, "Authentication token manager handled security error: %s", neo4jErr)
+	}
+	return handled
+}
+
+// Invalidates the routing table of a database the server does not know about, the router then also forgets
+// the users having it as home database
+func (s *sessionWithContext) handleDatabaseNotFound(ctx context.Context, err error) {
+	var neo4jErr *Neo4jError
+	if !errors.As(err, &neo4jErr) || !neo4jErr.IsDatabaseNotFound() {
+		return
+	}
+	if err := s.router.Invalidate(ctx, s.databaseName); err != nil {
+		s.log.Warnf(log.Session, s.logId, "Could not invalidate routing table of '%s': %s", s.databaseName, err)
 	}
 }
 
@@ -438,7 +628,11 @@ func (s *sessionWithContext) getConnection(ctx context.Context, mode idb.AccessM
 		return nil, wrapError(err)
 	}
 
-	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLogger, livenessCheckThreshold)
+	auth, err := s.getAuthToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLogger, livenessCheckThreshold, auth)
 	if err != nil {
 		return nil, wrapError(err)
 	}
@@ -456,14 +650,54 @@ func (s *sessionWithContext) getConnection(ctx context.Context, mode idb.AccessM
 	return conn, nil
 }
 
-func (s *sessionWithContext) retrieveBookmarks(conn idb.Connection) {
+// Returns a tracer for the operations of a transaction executed on the given connection
+func (s *sessionWithContext) newTracer(conn idb.Connection, mode idb.AccessMode, bookmarks Bookmarks) *tracer {
+	return newTracer(s.config, tracing.Attributes{
+		Database:      s.databaseName,
+		ServerAddress: conn.ServerName(),
+		AccessMode:    traceAccessMode(mode),
+		BookmarkCount: len(bookmarks),
+	})
+}
+
+func (s *sessionWithContext) retrieveBookmarks(conn idb.Connection) bool {
 	if conn == nil {
-		return
+		return false
 	}
 	bookmark := conn.Bookmark()
 	if len(bookmark) > 0 {
 		s.bookmarks = []string{bookmark}
+		return true
+	}
+	return false
+}
+
+// Retrieves the latest bookmark from the connection and notifies the bookmark manager, if any,
+// that the bookmarks sent at the beginning of the transaction have been superseded.
+func (s *sessionWithContext) updateBookmarks(ctx context.Context, conn idb.Connection, previousBookmarks Bookmarks) error {
+	if !s.retrieveBookmarks(conn) || s.bookmarkManager == nil {
+		return nil
+	}
+	return s.bookmarkManager.UpdateBookmarks(ctx, s.databaseName, previousBookmarks, s.bookmarks)
+}
+
+// Returns the bookmarks of the session, combined with the ones of the bookmark manager if any.
+// All the bookmarks of the bookmark manager are included as long as the home database is not resolved.
+func (s *sessionWithContext) getBookmarks(ctx context.Context) (Bookmarks, error) {
+	if s.bookmarkManager == nil {
+		return s.bookmarks, nil
+	}
+	var managedBookmarks Bookmarks
+	var err error
+	if s.resolveHomeDb {
+		managedBookmarks, err = s.bookmarkManager.GetAllBookmarks(ctx)
+	} else {
+		managedBookmarks, err = s.bookmarkManager.GetBookmarks(ctx, s.databaseName)
+	}
+	if err != nil {
+		return nil, err
 	}
+	return combineUniqueBookmarks(s.bookmarks, managedBookmarks), nil
 }
 
 func (s *sessionWithContext) Run(ctx context.Context,
@@ -476,7 +710,9 @@ func (s *sessionWithContext) Run(ctx context.Context,
 	}
 
 	if s.autocommitTx != nil {
-		s.autocommitTx.done(ctx)
+		if err := s.autocommitTx.done(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	config := defaultTransactionConfig()
@@ -487,37 +723,54 @@ func (s *sessionWithContext) Run(ctx context.Context,
 		return nil, err
 	}
 
-	conn, err := s.getConnection(ctx, s.defaultMode, pool.DefaultLivenessCheckThreshold)
+	conn, err := s.getConnection(ctx, s.defaultMode, s.config.ConnectionLivenessCheckTimeout)
 	if err != nil {
+		s.handleSecurityError(err)
 		return nil, err
 	}
 
+	runBookmarks, err := s.getBookmarks(ctx)
+	if err != nil {
+		s.pool.Return(ctx, conn)
+		return nil, err
+	}
+
+	runTracer := s.newTracer(conn, s.defaultMode, runBookmarks).withQuery(cypher)
+	runCtx, span := runTracer.start(ctx, tracing.Run)
 	stream, err := conn.Run(
-		ctx,
+		runCtx,
 		idb.Command{
 			Cypher:    cypher,
 			Params:    params,
 			FetchSize: s.fetchSize,
 		},
 		idb.TxConfig{
-			Mode:             s.defaultMode,
-			Bookmarks:        s.bookmarks,
-			Timeout:          config.Timeout,
-			Meta:             config.Metadata,
-			ImpersonatedUser: s.impersonatedUser,
+			Mode:               s.defaultMode,
+			Bookmarks:          runBookmarks,
+			Timeout:            config.Timeout,
+			Meta:               config.Metadata,
+			ImpersonatedUser:   s.impersonatedUser,
+			NotificationConfig: s.notificationConfig,
+			TelemetryAPI:       s.telemetryAPI(idb.TelemetryAutoCommitTransaction),
 		})
+	span.End(wrapError(err))
 	if err != nil {
+		s.handleSecurityError(err)
+		s.handleDatabaseNotFound(ctx, err)
 		s.pool.Return(ctx, conn)
 		return nil, wrapError(err)
 	}
 
+	result := newResultWithContext(conn, stream, cypher, params)
+	result.tracer = runTracer
 	s.autocommitTx = &autocommitTransaction{
 		conn: conn,
-		res:  newResultWithContext(conn, stream, cypher, params),
-		onClosed: func() {
-			s.retrieveBookmarks(conn)
+		res:  result,
+		onClosed: func(ctx context.Context) error {
+			err := s.updateBookmarks(ctx, conn, runBookmarks)
 			s.pool.Return(ctx, conn)
 			s.autocommitTx = nil
+			return err
 		},
 	}
 
@@ -531,7 +784,7 @@ func (s *sessionWithContext) Close(ctx context.Context) error {
 	}
 
 	if s.autocommitTx != nil {
-		s.autocommitTx.discard(ctx)
+		txErr = combineErrors(txErr, s.autocommitTx.discard(ctx))
 	}
 
 	defer s.log.Debugf(log.Session, s.logId, "Closed")
@@ -558,7 +811,11 @@ func (s *sessionWithContext) getServerInfo(ctx context.Context) (ServerInfo, err
 	if err != nil {
 		return nil, wrapError(err)
 	}
-	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLogger, 0)
+	auth, err := s.getAuthToken(ctx)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	conn, err := s.pool.Borrow(ctx, servers, s.config.ConnectionAcquisitionTimeout != 0, s.boltLogger, 0, auth)
 	if err != nil {
 		return nil, wrapError(err)
 	}
@@ -574,10 +831,19 @@ func (s *sessionWithContext) resolveHomeDatabase(ctx context.Context) error {
 	if !s.resolveHomeDb {
 		return nil
 	}
-	defaultDb, err := s.router.GetNameOfDefaultDatabase(ctx, s.bookmarks, s.impersonatedUser, s.boltLogger)
+	bookmarks, err := s.getBookmarks(ctx)
+	if err != nil {
+		return err
+	}
+	auth, err := s.getAuthToken(ctx)
+	if err != nil {
+		return err
+	}
+	defaultDb, err := s.router.GetNameOfDefaultDatabase(ctx, bookmarks, s.impersonatedUser, auth, s.boltLogger)
 	if err != nil {
 		return err
 	}
+	s.log = log.WithDatabase(s.log, defaultDb)
 	s.log.Debugf(log.Session, s.logId, "Resolved home database, uses db '%s'", defaultDb)
 	s.databaseName = defaultDb
 	s.resolveHomeDb = false
@@ -34,6 +34,7 @@ import (
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/retry"
 	. "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/testutil"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/log"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
 )
 
 type transactionFunc func(context.Context, ManagedTransactionWork, ...func(*TransactionConfig)) (any, error)
@@ -54,7 +55,7 @@ func TestSession(outer *testing.T) {
 		router := RouterFake{}
 		pool := PoolFake{}
 		sessConfig := SessionConfig{AccessMode: AccessModeRead, BoltLogger: boltLogger}
-		sess := newSessionWithContext(&conf, sessConfig, &router, &pool, logger)
+		sess := newSessionWithContext(&conf, sessConfig, &router, &pool, nil, logger)
 		sess.throttleTime = time.Millisecond * 1
 		return &router, &pool, sess
 	}
@@ -63,7 +64,7 @@ func TestSession(outer *testing.T) {
 		conf := Config{MaxTransactionRetryTime: 3 * time.Millisecond}
 		router := RouterFake{}
 		pool := PoolFake{}
-		sess := newSessionWithContext(&conf, sessConfig, &router, &pool, logger)
+		sess := newSessionWithContext(&conf, sessConfig, &router, &pool, nil, logger)
 		sess.throttleTime = time.Millisecond * 1
 		return &router, &pool, sess
 	}
@@ -230,6 +231,127 @@ func TestSession(outer *testing.T) {
 			_, _, sess := createSession()
 			AssertLen(t, sess.LastBookmarks(), 0)
 		})
+
+		inner.Run("Bookmark manager bookmarks are combined with session bookmarks", func(t *testing.T) {
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+				"mydb":  {"b1", "b2"},
+				"other": {"b3"},
+			}})
+			_, pool, sess := createSessionFromConfig(SessionConfig{
+				DatabaseName:    "mydb",
+				Bookmarks:       BookmarksFromRawValues("b1", "b0"),
+				BookmarkManager: bookmarkManager,
+			})
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			bookmarks := conn.RecordedTxs[0].Bookmarks
+			AssertLen(t, bookmarks, 3)
+			AssertDeepEquals(t, bookmarks[:2], []string{"b1", "b0"})
+			AssertStringEqual(t, bookmarks[2], "b2")
+		})
+
+		inner.Run("Bookmark manager is updated after each transaction", func(t *testing.T) {
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+				"mydb": {"b1"},
+			}})
+			sessConfig := SessionConfig{DatabaseName: "mydb", BookmarkManager: bookmarkManager}
+			_, pool, sess := createSessionFromConfig(sessConfig)
+			conn := &ConnFake{Alive: true, Bookm: "b2"}
+			pool.BorrowConn = conn
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+			AssertNoError(t, err)
+			bookmarks, _ := bookmarkManager.GetBookmarks(context.Background(), "mydb")
+			AssertDeepEquals(t, bookmarks, Bookmarks{"b2"})
+
+			conn.Bookm = "b3"
+			tx, err := sess.BeginTransaction(context.Background())
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(context.Background()))
+			bookmarks, _ = bookmarkManager.GetBookmarks(context.Background(), "mydb")
+			AssertDeepEquals(t, bookmarks, Bookmarks{"b3"})
+
+			conn.Bookm = "b4"
+			_, err = sess.Run(context.Background(), "cypher", nil)
+			AssertNoError(t, err)
+			AssertNoError(t, sess.Close(context.Background()))
+			bookmarks, _ = bookmarkManager.GetBookmarks(context.Background(), "mydb")
+			AssertDeepEquals(t, bookmarks, Bookmarks{"b4"})
+		})
+
+		inner.Run("Sessions sharing a bookmark manager are causally chained", func(t *testing.T) {
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{})
+			sessConfig := SessionConfig{DatabaseName: "mydb", BookmarkManager: bookmarkManager}
+			_, pool1, sess1 := createSessionFromConfig(sessConfig)
+			pool1.BorrowConn = &ConnFake{Alive: true, Bookm: "b1"}
+			_, pool2, sess2 := createSessionFromConfig(sessConfig)
+			conn2 := &ConnFake{Alive: true}
+			pool2.BorrowConn = conn2
+
+			_, err := sess1.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+			AssertNoError(t, err)
+			_, err = sess2.ExecuteRead(context.Background(), func(tx ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+			AssertNoError(t, err)
+
+			AssertLen(t, conn2.RecordedTxs, 1)
+			AssertDeepEquals(t, conn2.RecordedTxs[0].Bookmarks, []string{"b1"})
+		})
+
+		inner.Run("Bookmarks of the resolved home database are used for routing", func(t *testing.T) {
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{InitialBookmarks: map[string]Bookmarks{
+				"db1": {"b1"},
+			}})
+			router, pool, sess := createSessionFromConfig(SessionConfig{BookmarkManager: bookmarkManager})
+			pool.BorrowConn = &ConnFake{Alive: true}
+			var routingBookmarks []string
+			router.GetNameOfDefaultDbHook = func(user string) (string, error) {
+				return "db2", nil
+			}
+			router.WritersHook = func(bookmarks []string, database string) ([]string, error) {
+				routingBookmarks = bookmarks
+				return []string{"aserver"}, nil
+			}
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, routingBookmarks, 0)
+		})
+
+		inner.Run("Bookmark manager errors are not retried after commit", func(t *testing.T) {
+			consumerErr := errors.New("oopsie")
+			bookmarkManager := NewBookmarkManager(BookmarkManagerConfig{
+				BookmarkConsumer: func(context.Context, string, Bookmarks) error {
+					return consumerErr
+				},
+			})
+			_, pool, sess := createSessionFromConfig(SessionConfig{BookmarkManager: bookmarkManager})
+			pool.BorrowConn = &ConnFake{Alive: true, Bookm: "b1"}
+			executions := 0
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (interface{}, error) {
+				executions++
+				return nil, nil
+			})
+
+			AssertDeepEquals(t, err, consumerErr)
+			AssertIntEqual(t, executions, 1)
+		})
 	})
 
 	outer.Run("Run", func(inner *testing.T) {
@@ -359,6 +481,32 @@ func TestSession(outer *testing.T) {
 			AssertIntEqual(t, numDefaultDbLookups, 1)
 		})
 
+		inner.Run("Invalidates routing table of database not found", func(t *testing.T) {
+			router, pool, sess := createSession()
+			router.GetNameOfDefaultDbHook = func(string) (string, error) {
+				return "removed", nil
+			}
+			notFoundErr := &Neo4jError{Code: "Neo.ClientError.Database.DatabaseNotFound"}
+			pool.BorrowConn = &ConnFake{Alive: true, RunErr: notFoundErr}
+
+			_, err := sess.Run(context.Background(), "cypher", nil)
+
+			AssertDeepEquals(t, err, notFoundErr)
+			AssertTrue(t, router.Invalidated)
+			AssertStringEqual(t, router.InvalidatedDb, "removed")
+		})
+
+		inner.Run("Borrows with the configured liveness check timeout", func(t *testing.T) {
+			_, pool, sess := createSession()
+			sess.config.ConnectionLivenessCheckTimeout = 5 * time.Minute
+			pool.BorrowConn = &ConnFake{Alive: true}
+
+			_, err := sess.Run(context.Background(), "cypher", nil)
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, pool.BorrowLivenessCheckThreshold, 5*time.Minute)
+		})
+
 		inner.Run("Token expiration in session run after errored connection acquisition", func(t *testing.T) {
 			_, pool, sess := createSession()
 			pool.BorrowErr = tokenExpiredErr
@@ -645,6 +793,349 @@ func TestSession(outer *testing.T) {
 		})
 	})
 
+	outer.Run("Authentication", func(inner *testing.T) {
+		driverAuth := BasicAuth("driver", "pass", "")
+
+		inner.Run("borrows connections with the driver token by default", func(t *testing.T) {
+			conf := Config{MaxTransactionRetryTime: 3 * time.Millisecond}
+			pool := PoolFake{BorrowConn: &ConnFake{Alive: true}}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &pool, driverAuth, logger)
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, pool.BorrowAuth, &idb.ReAuthToken{Token: driverAuth.tokens})
+		})
+
+		inner.Run("borrows connections with the session token", func(t *testing.T) {
+			conf := Config{MaxTransactionRetryTime: 3 * time.Millisecond}
+			pool := PoolFake{BorrowConn: &ConnFake{Alive: true}}
+			sessionAuth := BearerAuth("token")
+			sess := newSessionWithContext(&conf, SessionConfig{Auth: &sessionAuth}, &RouterFake{}, &pool, driverAuth, logger)
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (interface{}, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, pool.BorrowAuth, &idb.ReAuthToken{Token: sessionAuth.tokens, FromSession: true})
+		})
+
+		inner.Run("retries expired tokens refreshed by the token manager", func(t *testing.T) {
+			conf := Config{MaxTransactionRetryTime: time.Second}
+			pool := PoolFake{BorrowConn: &ConnFake{Alive: true}}
+			tokens := []string{"expired", "fresh"}
+			fetches := 0
+			manager := BearerAuthTokenManager(func(context.Context) (AuthToken, *time.Time, error) {
+				fetches++
+				return BearerAuth(tokens[fetches-1]), nil, nil
+			})
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &pool, manager, logger)
+			sess.throttleTime = time.Millisecond * 1
+			attempts := 0
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (interface{}, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, tokenExpiredErr
+				}
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertIntEqual(t, attempts, 2)
+			AssertDeepEquals(t, pool.BorrowAuth, &idb.ReAuthToken{Token: BearerAuth("fresh").tokens})
+		})
+
+		inner.Run("does not retry expired static tokens", func(t *testing.T) {
+			conf := Config{MaxTransactionRetryTime: time.Second}
+			pool := PoolFake{BorrowConn: &ConnFake{Alive: true}}
+			sess := newSessionWithContext(&conf, SessionConfig{}, &RouterFake{}, &pool, BearerAuth("token"), logger)
+			attempts := 0
+
+			_, err := sess.ExecuteRead(context.Background(), func(ManagedTransaction) (interface{}, error) {
+				attempts++
+				return nil, tokenExpiredErr
+			})
+
+			assertTokenExpiredError(t, err)
+			AssertIntEqual(t, attempts, 1)
+		})
+	})
+
+	outer.Run("Streaming", func(inner *testing.T) {
+		ctx := context.Background()
+		records := func(count int) []Next {
+			nexts := make([]Next, count)
+			for i := range nexts {
+				nexts[i] = Next{Record: &db.Record{Keys: []string{"n"}, Values: []any{i}}}
+			}
+			return append(nexts, Next{Summary: &db.Summary{}})
+		}
+
+		inner.Run("returns the connection of abandoned streams when closed", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true, Nexts: records(10)}
+			returned := false
+			pool.ReturnHook = func() {
+				returned = true
+			}
+			result, err := sess.Run(ctx, "UNWIND range(0, 9) AS n RETURN n", nil)
+			AssertNoError(t, err)
+
+			stream := result.Stream(ctx, 0)
+			<-stream
+			AssertNoError(t, sess.Close(ctx))
+
+			AssertTrue(t, returned)
+			for range stream {
+			}
+		})
+
+		inner.Run("stops streams when the transaction ends", func(t *testing.T) {
+			_, pool, sess := createSession()
+			pool.BorrowConn = &ConnFake{Alive: true, Nexts: records(10)}
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			result, err := tx.Run(ctx, "UNWIND range(0, 9) AS n RETURN n", nil)
+			AssertNoError(t, err)
+
+			stream := result.Stream(ctx, 0)
+			<-stream
+			AssertNoError(t, tx.Commit(ctx))
+
+			for range stream {
+			}
+		})
+	})
+
+	outer.Run("Tracing", func(inner *testing.T) {
+		ctx := context.Background()
+
+		createTracedSession := func(config Config, sessConfig SessionConfig) (*PoolFake, *TracerFake, *sessionWithContext) {
+			tracer := &TracerFake{}
+			config.MaxTransactionRetryTime = 3 * time.Millisecond
+			config.Tracer = tracer
+			pool := PoolFake{}
+			sess := newSessionWithContext(&config, sessConfig, &RouterFake{}, &pool, nil, logger)
+			sess.throttleTime = time.Millisecond * 1
+			return &pool, tracer, sess
+		}
+		sessConfig := SessionConfig{
+			AccessMode:   AccessModeWrite,
+			DatabaseName: "db1",
+			Bookmarks:    BookmarksFromRawValues("b1", "b2"),
+		}
+		expectedAttributes := tracing.Attributes{
+			Database:      "db1",
+			ServerAddress: "server1",
+			AccessMode:    "write",
+			BookmarkCount: 2,
+		}
+
+		inner.Run("traces auto-commit runs and pulls", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{}, sessConfig)
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true, Nexts: []Next{
+				{Record: &db.Record{Keys: []string{"n"}, Values: []any{1}}},
+				{Summary: &db.Summary{}},
+			}}
+
+			result, err := sess.Run(ctx, "RETURN 1 AS n", nil)
+			AssertNoError(t, err)
+			_, err = result.Collect(ctx)
+			AssertNoError(t, err)
+
+			AssertDeepEquals(t, tracer.Operations(), []tracing.Operation{tracing.Run, tracing.Pull})
+			attributesWithQuery := expectedAttributes
+			attributesWithQuery.Query = "RETURN 1 AS n"
+			for _, span := range tracer.Spans() {
+				AssertDeepEquals(t, span.Attributes, attributesWithQuery)
+				AssertTrue(t, span.Ended)
+				AssertNoError(t, span.Err)
+			}
+		})
+
+		inner.Run("redacts queries", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{RedactQueriesInTraces: true}, sessConfig)
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true}
+
+			_, err := sess.Run(ctx, "RETURN 'secret'", nil)
+			AssertNoError(t, err)
+
+			AssertDeepEquals(t, tracer.Spans()[0].Attributes, expectedAttributes)
+		})
+
+		inner.Run("reports failed runs", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{}, sessConfig)
+			runErr := &db.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError"}
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true, RunErr: runErr}
+
+			_, err := sess.Run(ctx, "RETURN", nil)
+
+			AssertError(t, err)
+			spans := tracer.Spans()
+			AssertLen(t, spans, 1)
+			AssertTrue(t, spans[0].Ended)
+			AssertDeepEquals(t, spans[0].Err, runErr)
+		})
+
+		inner.Run("traces explicit transactions", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{}, sessConfig)
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true}
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			_, err = tx.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Commit(ctx))
+			tx, err = sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Rollback(ctx))
+
+			AssertDeepEquals(t, tracer.Operations(), []tracing.Operation{
+				tracing.Begin, tracing.Run, tracing.Commit, tracing.Begin, tracing.Rollback,
+			})
+			spans := tracer.Spans()
+			AssertDeepEquals(t, spans[0].Attributes, expectedAttributes)
+			AssertStringEqual(t, spans[1].Attributes.Query, "RETURN 1")
+			for _, span := range spans {
+				AssertTrue(t, span.Ended)
+			}
+		})
+
+		inner.Run("ends pulls of results cut short by their transaction", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{}, sessConfig)
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true, Nexts: []Next{
+				{Record: &db.Record{Keys: []string{"n"}, Values: []any{1}}},
+				{Record: &db.Record{Keys: []string{"n"}, Values: []any{2}}},
+			}}
+
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			result, err := tx.Run(ctx, "UNWIND [1, 2] AS n RETURN n", nil)
+			AssertNoError(t, err)
+			AssertTrue(t, result.Next(ctx))
+			AssertNoError(t, tx.Commit(ctx))
+
+			AssertDeepEquals(t, tracer.Operations(), []tracing.Operation{
+				tracing.Begin, tracing.Run, tracing.Pull, tracing.Commit,
+			})
+			for _, span := range tracer.Spans() {
+				AssertTrue(t, span.Ended)
+			}
+		})
+
+		inner.Run("ends pulls of results cut short by their transaction function", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{}, sessConfig)
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true, Nexts: []Next{
+				{Record: &db.Record{Keys: []string{"n"}, Values: []any{1}}},
+				{Record: &db.Record{Keys: []string{"n"}, Values: []any{2}}},
+			}}
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				result, err := tx.Run(ctx, "UNWIND [1, 2] AS n RETURN n", nil)
+				if err != nil {
+					return nil, err
+				}
+				result.Next(ctx)
+				return nil, result.Err()
+			})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, tracer.Operations(), []tracing.Operation{
+				tracing.Begin, tracing.Run, tracing.Pull, tracing.Commit,
+			})
+			for _, span := range tracer.Spans() {
+				AssertTrue(t, span.Ended)
+			}
+		})
+
+		inner.Run("traces transaction function retries", func(t *testing.T) {
+			pool, tracer, sess := createTracedSession(Config{}, sessConfig)
+			pool.BorrowConn = &ConnFake{Name: "server1", Alive: true}
+			transientErr := &db.Neo4jError{Code: "Neo.TransientError.General.MemoryPoolOutOfMemoryError"}
+			attempts := 0
+
+			_, err := sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				attempts++
+				if attempts == 1 {
+					return nil, transientErr
+				}
+				_, err := tx.Run(ctx, "RETURN 1", nil)
+				return nil, err
+			})
+
+			AssertNoError(t, err)
+			AssertDeepEquals(t, tracer.Operations(), []tracing.Operation{
+				tracing.Begin, tracing.Retry, tracing.Begin, tracing.Run, tracing.Commit,
+			})
+			spans := tracer.Spans()
+			retrySpan := spans[1]
+			AssertStringEqual(t, retrySpan.Attributes.RetryCause, "Transient error")
+			AssertStringEqual(t, retrySpan.Attributes.Database, "db1")
+			AssertTrue(t, retrySpan.Ended)
+			AssertNoError(t, retrySpan.Err)
+			AssertNil(t, spans[0].Parent)
+			AssertTrue(t, spans[2].Parent == retrySpan)
+			AssertTrue(t, spans[4].Parent == retrySpan)
+		})
+	})
+
+	outer.Run("Telemetry", func(inner *testing.T) {
+		inner.Run("Reports the API starting each transaction", func(t *testing.T) {
+			_, pool, sess := createSession()
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+			ctx := context.Background()
+
+			_, err := sess.Run(ctx, "RETURN 1", nil)
+			AssertNoError(t, err)
+			tx, err := sess.BeginTransaction(ctx)
+			AssertNoError(t, err)
+			AssertNoError(t, tx.Close(ctx))
+			_, err = sess.ExecuteWrite(ctx, func(tx ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+			AssertNoError(t, err)
+
+			AssertLen(t, conn.RecordedTxs, 3)
+			AssertIntEqual(t, int(conn.RecordedTxs[0].TelemetryAPI), int(idb.TelemetryAutoCommitTransaction))
+			AssertIntEqual(t, int(conn.RecordedTxs[1].TelemetryAPI), int(idb.TelemetryUnmanagedTransaction))
+			AssertIntEqual(t, int(conn.RecordedTxs[2].TelemetryAPI), int(idb.TelemetryManagedTransaction))
+		})
+
+		inner.Run("Reports sessions configured by ExecuteQuery", func(t *testing.T) {
+			_, pool, sess := createSessionFromConfig((&ExecuteQueryConfiguration{}).toSessionConfig())
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.ExecuteRead(context.Background(), func(tx ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertIntEqual(t, int(conn.RecordedTxs[0].TelemetryAPI), int(idb.TelemetryExecuteQuery))
+		})
+
+		inner.Run("Reports nothing when disabled", func(t *testing.T) {
+			_, pool, sess := createSession()
+			sess.config.TelemetryDisabled = true
+			conn := &ConnFake{Alive: true}
+			pool.BorrowConn = conn
+
+			_, err := sess.ExecuteWrite(context.Background(), func(tx ManagedTransaction) (any, error) {
+				return nil, nil
+			})
+
+			AssertNoError(t, err)
+			AssertLen(t, conn.RecordedTxs, 1)
+			AssertIntEqual(t, int(conn.RecordedTxs[0].TelemetryAPI), int(idb.TelemetryNone))
+		})
+	})
+
 	outer.Run("Close", func(ct *testing.T) {
 		ct.Run("Cleans up connection pool async", func(t *testing.T) {
 			_, pool, sess := createSession()
@@ -60,7 +60,7 @@ func makeRawConnection(logger log.Logger, boltLogger log.BoltLogger) (
 		"credentials": server.Password,
 	}
 
-	boltConn, err := bolt.Connect(context.Background(), parsedUri.Host, tcpConn, authMap, "007", nil, logger, boltLogger)
+	boltConn, err := bolt.Connect(context.Background(), parsedUri.Host, tcpConn, authMap, "007", "neo4j-go/test", nil, idb.NotificationConfig{}, bolt.ValueConfig{}, logger, boltLogger)
 	if err != nil {
 		panic(err)
 	}
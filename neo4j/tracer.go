@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package neo4j
+
+import (
+	"context"
+
+	idb "github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
+)
+
+// Starts spans on the configured tracer with the attributes of the traced operation.
+// A nil tracer does not trace anything.
+type tracer struct {
+	delegate      tracing.Tracer
+	redactQueries bool
+	attributes    tracing.Attributes
+}
+
+func newTracer(config *Config, attributes tracing.Attributes) *tracer {
+	if config.Tracer == nil {
+		return nil
+	}
+	return &tracer{
+		delegate:      config.Tracer,
+		redactQueries: config.RedactQueriesInTraces,
+		attributes:    attributes,
+	}
+}
+
+func (t *tracer) start(ctx context.Context, operation tracing.Operation) (context.Context, tracing.Span) {
+	if t == nil {
+		return tracing.Void{}.StartSpan(ctx, operation, tracing.Attributes{})
+	}
+	return t.delegate.StartSpan(ctx, operation, t.attributes)
+}
+
+// Returns a tracer for the operations related to the given query
+func (t *tracer) withQuery(cypher string) *tracer {
+	if t == nil || t.redactQueries {
+		return t
+	}
+	result := *t
+	result.attributes.Query = cypher
+	return &result
+}
+
+func traceAccessMode(mode idb.AccessMode) string {
+	if mode == idb.ReadMode {
+		return "read"
+	}
+	return "write"
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) "Neo4j"
+ * Neo4j Sweden AB [https://neo4j.com]
+ *
+ * This file is part of Neo4j.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+// Package tracing defines the hooks the driver calls around every round trip with the server.
+//
+// Implement Tracer to bridge the driver with a distributed tracing system such as OpenTelemetry.
+package tracing
+
+import "context"
+
+// Operation identifies the driver operation a span covers
+type Operation string
+
+const (
+	// Begin covers the start of an explicit or managed transaction
+	Begin Operation = "begin"
+	// Run covers the submission of a query, in an auto-commit or in an explicit transaction
+	Run Operation = "run"
+	// Pull covers the retrieval of the records of a result, until its summary is received
+	Pull Operation = "pull"
+	// Commit covers the commit of a transaction
+	Commit Operation = "commit"
+	// Rollback covers the rollback of an explicit transaction
+	Rollback Operation = "rollback"
+	// Retry covers a new attempt of a transaction function, including the delay before the attempt
+	Retry Operation = "retry"
+)
+
+// Attributes describe the operation a span covers.
+// Attributes that do not apply to the operation are left to their zero value.
+type Attributes struct {
+	// Database is the name of the targeted database, empty for the default database
+	Database string
+	// ServerAddress is the address of the server the operation is executed against
+	ServerAddress string
+	// Query is the Cypher query text, empty when query redaction is enabled
+	Query string
+	// AccessMode is either "read" or "write"
+	AccessMode string
+	// BookmarkCount is the number of bookmarks sent along with the operation
+	BookmarkCount int
+	// RetryCause describes why a transaction function is retried
+	RetryCause string
+}
+
+// Tracer starts spans around the driver operations.
+// Implementations must be safe for concurrent use, since a driver is shared by many sessions.
+type Tracer interface {
+	// StartSpan starts a span for the given operation.
+	// The returned context is used for the rest of the operation, which allows
+	// implementations to attach the span to it, so that nested operations become child spans.
+	StartSpan(ctx context.Context, operation Operation, attributes Attributes) (context.Context, Span)
+}
+
+// Span is a started operation
+type Span interface {
+	// End ends the span, err is nil if the operation succeeded
+	End(err error)
+}
+
+// Void is a Tracer implementation that does not record anything
+type Void struct{}
+
+func (t Void) StartSpan(ctx context.Context, _ Operation, _ Attributes) (context.Context, Span) {
+	return ctx, voidSpan{}
+}
+
+type voidSpan struct{}
+
+func (s voidSpan) End(error) {
+}
@@ -22,6 +22,7 @@ package neo4j
 import (
 	"context"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j/internal/db"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/tracing"
 )
 
 // ManagedTransaction represents a transaction managed by the driver and operated on by the user, via transaction functions
@@ -57,19 +58,28 @@ type explicitTransaction struct {
 	done      bool
 	runFailed bool
 	err       error
-	onClosed  func()
+	onClosed  func(context.Context) error
+	tracer    *tracer
+	// results of the transaction, their streams are stopped and their pull spans ended when the transaction ends
+	results []*resultWithContext
 }
 
 func (tx *explicitTransaction) Run(ctx context.Context, cypher string,
 	params map[string]interface{}) (ResultWithContext, error) {
-	stream, err := tx.conn.RunTx(ctx, tx.txHandle, db.Command{Cypher: cypher, Params: params, FetchSize: tx.fetchSize})
+	runTracer := tx.tracer.withQuery(cypher)
+	runCtx, span := runTracer.start(ctx, tracing.Run)
+	stream, err := tx.conn.RunTx(runCtx, tx.txHandle, db.Command{Cypher: cypher, Params: params, FetchSize: tx.fetchSize})
+	span.End(wrapError(err))
 	if err != nil {
 		tx.err = err
 		tx.runFailed = true
-		tx.onClosed()
+		tx.closeWith(ctx)
 		return nil, wrapError(tx.err)
 	}
-	return newResultWithContext(tx.conn, stream, cypher, params), nil
+	result := newResultWithContext(tx.conn, stream, cypher, params)
+	result.tracer = runTracer
+	tx.results = append(tx.results, result)
+	return result, nil
 }
 
 func (tx *explicitTransaction) Commit(ctx context.Context) error {
@@ -80,9 +90,12 @@ func (tx *explicitTransaction) Commit(ctx context.Context) error {
 	if tx.done {
 		return transactionAlreadyCompletedError()
 	}
-	tx.err = tx.conn.TxCommit(ctx, tx.txHandle)
+	stopStreams(tx.results)
+	commitCtx, span := tx.tracer.start(ctx, tracing.Commit)
+	tx.err = tx.conn.TxCommit(commitCtx, tx.txHandle)
+	span.End(wrapError(tx.err))
 	tx.done = true
-	tx.onClosed()
+	tx.closeWith(ctx)
 	return wrapError(tx.err)
 }
 
@@ -102,17 +115,30 @@ func (tx *explicitTransaction) Rollback(ctx context.Context) error {
 	if tx.done {
 		return transactionAlreadyCompletedError()
 	}
+	stopStreams(tx.results)
 	if !tx.conn.IsAlive() || tx.conn.HasFailed() {
 		// tx implicitly rolled back by having failed
 		tx.err = nil
 	} else {
-		tx.err = tx.conn.TxRollback(ctx, tx.txHandle)
+		rollbackCtx, span := tx.tracer.start(ctx, tracing.Rollback)
+		tx.err = tx.conn.TxRollback(rollbackCtx, tx.txHandle)
+		span.End(wrapError(tx.err))
 	}
 	tx.done = true
-	tx.onClosed()
+	tx.closeWith(ctx)
 	return wrapError(tx.err)
 }
 
+// Runs the closing hook, its error only surfaces if the transaction did not fail beforehand
+func (tx *explicitTransaction) closeWith(ctx context.Context) {
+	stopStreams(tx.results)
+	endPulls(tx.results, tx.err)
+	tx.results = nil
+	if err := tx.onClosed(ctx); tx.err == nil {
+		tx.err = err
+	}
+}
+
 func (tx *explicitTransaction) legacy() Transaction {
 	return &transaction{
 		delegate: tx,
@@ -124,14 +150,30 @@ type managedTransaction struct {
 	conn      db.Connection
 	fetchSize int
 	txHandle  db.TxHandle
+	tracer    *tracer
+	// results of the transaction, their streams are stopped and their pull spans ended when the transaction ends
+	results []*resultWithContext
 }
 
 func (tx *managedTransaction) Run(ctx context.Context, cypher string, params map[string]interface{}) (ResultWithContext, error) {
-	stream, err := tx.conn.RunTx(ctx, tx.txHandle, db.Command{Cypher: cypher, Params: params, FetchSize: tx.fetchSize})
+	runTracer := tx.tracer.withQuery(cypher)
+	runCtx, span := runTracer.start(ctx, tracing.Run)
+	stream, err := tx.conn.RunTx(runCtx, tx.txHandle, db.Command{Cypher: cypher, Params: params, FetchSize: tx.fetchSize})
+	span.End(wrapError(err))
 	if err != nil {
 		return nil, wrapError(err)
 	}
-	return newResultWithContext(tx.conn, stream, cypher, params), nil
+	result := newResultWithContext(tx.conn, stream, cypher, params)
+	result.tracer = runTracer
+	tx.results = append(tx.results, result)
+	return result, nil
+}
+
+// Stops streaming the results of the transaction and ends the pull spans they left open
+func (tx *managedTransaction) endPulls(err error) {
+	stopStreams(tx.results)
+	endPulls(tx.results, err)
+	tx.results = nil
 }
 
 // legacy interop only - remove in 6.0
@@ -162,23 +204,25 @@ type autocommitTransaction struct {
 	conn     db.Connection
 	res      *resultWithContext
 	closed   bool
-	onClosed func()
+	onClosed func(context.Context) error
 }
 
-func (tx *autocommitTransaction) done(ctx context.Context) {
+func (tx *autocommitTransaction) done(ctx context.Context) error {
 	if !tx.closed {
 		tx.res.buffer(ctx)
 		tx.closed = true
-		tx.onClosed()
+		return tx.onClosed(ctx)
 	}
+	return nil
 }
 
-func (tx *autocommitTransaction) discard(ctx context.Context) {
+func (tx *autocommitTransaction) discard(ctx context.Context) error {
 	if !tx.closed {
 		tx.res.Consume(ctx)
 		tx.closed = true
-		tx.onClosed()
+		return tx.onClosed(ctx)
 	}
+	return nil
 }
 
 func transactionAlreadyCompletedError() *UsageError {
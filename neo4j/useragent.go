@@ -19,4 +19,10 @@
 
 package neo4j
 
-const UserAgent = "Go Driver/5.0"
+// Version of the driver, the user agent and the bolt agent are derived from it
+const driverVersion = "5.0"
+
+const UserAgent = "Go Driver/" + driverVersion
+
+// Product identifying the driver in the bolt agent sent to servers supporting it
+const boltAgentProduct = "neo4j-go/" + driverVersion
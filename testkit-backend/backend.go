@@ -366,30 +366,7 @@ func (b *backend) handleRequest(req map[string]interface{}) {
 
 	case "NewDriver":
 		// Parse authorization token
-		var authToken neo4j.AuthToken
-		authTokenMap := data["authorizationToken"].(map[string]interface{})["data"].(map[string]interface{})
-		switch authTokenMap["scheme"] {
-		case "basic":
-			realm, ok := authTokenMap["realm"].(string)
-			if !ok {
-				realm = ""
-			}
-			authToken = neo4j.BasicAuth(
-				authTokenMap["principal"].(string),
-				authTokenMap["credentials"].(string),
-				realm)
-		case "kerberos":
-			authToken = neo4j.KerberosAuth(authTokenMap["credentials"].(string))
-		case "bearer":
-			authToken = neo4j.BearerAuth(authTokenMap["credentials"].(string))
-		default:
-			authToken = neo4j.CustomAuth(
-				authTokenMap["scheme"].(string),
-				authTokenMap["principal"].(string),
-				authTokenMap["credentials"].(string),
-				authTokenMap["realm"].(string),
-				authTokenMap["parameters"].(map[string]interface{}))
-		}
+		authToken := parseAuthToken(data["authorizationToken"].(map[string]interface{})["data"].(map[string]interface{}))
 		// Parse URI (or rather type cast)
 		uri := data["uri"].(string)
 		driver, err := neo4j.NewDriverWithContext(uri, authToken, func(c *neo4j.Config) {
@@ -418,6 +395,15 @@ func (b *backend) handleRequest(req map[string]interface{}) {
 			if data["connectionTimeoutMs"] != nil {
 				c.SocketConnectTimeout = time.Millisecond * time.Duration(data["connectionTimeoutMs"].(float64))
 			}
+			if data["telemetryDisabled"] != nil {
+				c.TelemetryDisabled = data["telemetryDisabled"].(bool)
+			}
+			if data["notificationsMinSeverity"] != nil {
+				c.NotificationsMinSeverity = neo4j.NotificationMinSeverityLevel(data["notificationsMinSeverity"].(string))
+			}
+			if data["notificationsDisabledCategories"] != nil {
+				c.NotificationsDisabledCategories = parseNotificationCategories(data["notificationsDisabledCategories"].([]interface{}))
+			}
 		})
 		if err != nil {
 			b.writeError(err)
@@ -483,6 +469,16 @@ func (b *backend) handleRequest(req map[string]interface{}) {
 		if data["impersonatedUser"] != nil {
 			sessionConfig.ImpersonatedUser = data["impersonatedUser"].(string)
 		}
+		if data["authorizationToken"] != nil {
+			authToken := parseAuthToken(data["authorizationToken"].(map[string]interface{})["data"].(map[string]interface{}))
+			sessionConfig.Auth = &authToken
+		}
+		if data["notificationsMinSeverity"] != nil {
+			sessionConfig.NotificationsMinSeverity = neo4j.NotificationMinSeverityLevel(data["notificationsMinSeverity"].(string))
+		}
+		if data["notificationsDisabledCategories"] != nil {
+			sessionConfig.NotificationsDisabledCategories = parseNotificationCategories(data["notificationsDisabledCategories"].([]interface{}))
+		}
 		session := driver.NewSession(ctx, sessionConfig)
 		idKey := b.nextId()
 		b.sessionStates[idKey] = &sessionState{session: session}
@@ -735,6 +731,9 @@ func (b *backend) handleRequest(req map[string]interface{}) {
 				"Feature:API:Liveness.Check",
 				"Feature:API:Result.List",
 				"Feature:API:Result.Peek",
+				"Feature:API:Driver:NotificationsConfig",
+				"Feature:API:Session:AuthConfig",
+				"Feature:API:Session:NotificationsConfig",
 				"Feature:Auth:Custom",
 				"Feature:Auth:Bearer",
 				"Feature:Auth:Kerberos",
@@ -744,6 +743,10 @@ func (b *backend) handleRequest(req map[string]interface{}) {
 				"Feature:Bolt:4.3",
 				"Feature:Bolt:4.4",
 				"Feature:Bolt:5.0",
+				"Feature:Bolt:5.1",
+				"Feature:Bolt:5.2",
+				"Feature:Bolt:5.3",
+				"Feature:Bolt:5.4",
 				"Feature:Impersonation",
 				"Feature:TLS:1.1",
 				"Feature:TLS:1.2",
@@ -769,6 +772,31 @@ func (b *backend) handleRequest(req map[string]interface{}) {
 	}
 }
 
+func parseAuthToken(authTokenMap map[string]interface{}) neo4j.AuthToken {
+	switch authTokenMap["scheme"] {
+	case "basic":
+		realm, ok := authTokenMap["realm"].(string)
+		if !ok {
+			realm = ""
+		}
+		return neo4j.BasicAuth(
+			authTokenMap["principal"].(string),
+			authTokenMap["credentials"].(string),
+			realm)
+	case "kerberos":
+		return neo4j.KerberosAuth(authTokenMap["credentials"].(string))
+	case "bearer":
+		return neo4j.BearerAuth(authTokenMap["credentials"].(string))
+	default:
+		return neo4j.CustomAuth(
+			authTokenMap["scheme"].(string),
+			authTokenMap["principal"].(string),
+			authTokenMap["credentials"].(string),
+			authTokenMap["realm"].(string),
+			authTokenMap["parameters"].(map[string]interface{}))
+	}
+}
+
 func (b *backend) writeRecord(result neo4j.ResultWithContext, record *neo4j.Record, expectRecord bool) {
 	if expectRecord && record == nil {
 		b.writeResponse("BackendError", map[string]interface{}{
@@ -820,7 +848,7 @@ func asRegex(rawPattern string) *regexp.Regexp {
 }
 
 func serializeRecord(record *neo4j.Record) map[string]interface{} {
-	values := record.Values
+	values := record.AllValues()
 	cypherValues := make([]interface{}, len(values))
 	for i, v := range values {
 		cypherValues[i] = nativeToCypher(v)
@@ -839,10 +867,14 @@ func serializeNotifications(slice []neo4j.Notification) []map[string]interface{}
 	var res []map[string]interface{}
 	for i, notification := range slice {
 		res = append(res, map[string]interface{}{
-			"code":        notification.Code(),
-			"title":       notification.Title(),
-			"description": notification.Description(),
-			"severity":    notification.Severity(),
+			"code":             notification.Code(),
+			"title":            notification.Title(),
+			"description":      notification.Description(),
+			"severity":         notification.Severity(),
+			"severityLevel":    string(notification.SeverityLevel()),
+			"rawSeverityLevel": notification.Severity(),
+			"category":         string(notification.Category()),
+			"rawCategory":      notification.RawCategory(),
 		})
 		if notification.Position() != nil {
 			res[i]["position"] = map[string]interface{}{
@@ -855,6 +887,14 @@ func serializeNotifications(slice []neo4j.Notification) []map[string]interface{}
 	return res
 }
 
+func parseNotificationCategories(rawCategories []interface{}) []neo4j.NotificationCategory {
+	categories := make([]neo4j.NotificationCategory, len(rawCategories))
+	for i, rawCategory := range rawCategories {
+		categories[i] = neo4j.NotificationCategory(rawCategory.(string))
+	}
+	return categories
+}
+
 func serializePlan(plan neo4j.Plan) map[string]interface{} {
 	if plan == nil {
 		return nil